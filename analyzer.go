@@ -5,30 +5,50 @@ package zerologlintctx
 import (
 	"errors"
 	"go/ast"
+	"go/types"
 
 	"golang.org/x/tools/go/analysis"
-	"golang.org/x/tools/go/analysis/passes/buildssa"
 
 	"github.com/mpyw/zerologlintctx/internal"
+	"github.com/mpyw/zerologlintctx/internal/config"
 	"github.com/mpyw/zerologlintctx/internal/directive"
+	"github.com/mpyw/zerologlintctx/internal/facts"
 	"github.com/mpyw/zerologlintctx/internal/typeutil"
 )
 
 // Analyzer is the main analyzer for zerologlintctx.
 var Analyzer = &analysis.Analyzer{
-	Name:     "zerologlintctx",
-	Doc:      "checks that context.Context is properly propagated to zerolog logging chains via .Ctx(ctx)",
-	Requires: []*analysis.Analyzer{buildssa.Analyzer},
-	Run:      run,
+	Name: "zerologlintctx",
+	Doc:  "checks that context.Context is properly propagated to zerolog logging chains via .Ctx(ctx)",
+	Run:  run,
+	FactTypes: []analysis.Fact{
+		new(facts.ReturnFact),
+		new(facts.ConsumeFact),
+		new(facts.ProviderFact),
+		new(facts.ReadsCtxFact),
+		new(facts.HookedFact),
+	},
 }
 
+// cfg holds the settings bound to Analyzer.Flags.
+var cfg = config.Default()
+
+func init() {
+	cfg.RegisterFlags(&Analyzer.Flags)
+}
+
+// ErrNoSSA is returned when SSA form is unavailable.
+//
+// Deprecated: SSA is now built by the analyzer itself (see internal.BuildSSA)
+// and this error is no longer returned.
 var ErrNoSSA = errors.New("SSA analyzer result not found")
 
 func run(pass *analysis.Pass) (any, error) {
-	ssaInfo, ok := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
-	if !ok {
-		return nil, ErrNoSSA
-	}
+	// Recognize configured zerolog forks and log packages
+	pkgs := typeutil.NewPackages(cfg.ZerologPkgs, cfg.ZerologLogPkgs)
+
+	// Build SSA only for packages that can reach zerolog (nil otherwise)
+	ssaInfo := internal.BuildSSA(pass, pkgs)
 
 	// Build set of files to skip
 	skipFiles := buildSkipFiles(pass)
@@ -37,11 +57,24 @@ func run(pass *analysis.Pass) (any, error) {
 	ignoreMaps := buildIgnoreMaps(pass, skipFiles)
 
 	// Run SSA-based zerolog analysis
-	internal.RunSSA(pass, ssaInfo, ignoreMaps, skipFiles, typeutil.IsContextType)
+	internal.RunSSA(pass, ssaInfo, ignoreMaps, skipFiles, contextTypeFunc(cfg.CtxTypes), pkgs, cfg)
 
 	return nil, nil
 }
 
+// contextTypeFunc returns the predicate for parameter types accepted as a
+// context under the given mode.
+func contextTypeFunc(mode config.CtxTypes) func(types.Type) bool {
+	switch mode {
+	case config.CtxTypesExact:
+		return typeutil.IsContextType
+	case config.CtxTypesAll:
+		return typeutil.ImplementsContext
+	default:
+		return typeutil.EmbedsContext
+	}
+}
+
 // buildSkipFiles creates a set of filenames to skip.
 // Generated files are always skipped.
 // Test files can be skipped via the driver's built-in -test flag.
@@ -1,6 +1,7 @@
 package zerologlintctx_test
 
 import (
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/go/analysis/analysistest"
@@ -18,3 +19,151 @@ func TestFileFilter(t *testing.T) {
 	// Tests that generated files are skipped
 	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "filefilter")
 }
+
+func TestCrossPackage(t *testing.T) {
+	testdata := analysistest.TestData()
+	// Tests that helper summaries are exported as facts and used by importers
+	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "crosspkg/...")
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	// Tests both styles of fixes for missing .Ctx(ctx)
+	analysistest.RunWithSuggestedFixes(t, testdata, zerologlintctx.Analyzer, "suggestfix")
+}
+
+func TestFixStyle(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "fix-style", "zerolog-ctx")
+	// Tests that the preferred style comes first, so that -fix applies it.
+	// Lossy roots (local or built loggers) only get the .Ctx(ctx) insertion.
+	for _, result := range analysistest.Run(t, testdata, zerologlintctx.Analyzer, "suggestfix") {
+		for _, diag := range result.Diagnostics {
+			if len(diag.SuggestedFixes) == 0 {
+				t.Errorf("%s: no suggested fixes", diag.Message)
+				continue
+			}
+			if len(diag.SuggestedFixes) == 1 {
+				continue
+			}
+			if msg := diag.SuggestedFixes[0].Message; !strings.HasPrefix(msg, "Use zerolog.Ctx") {
+				t.Errorf("%s: first fix is %q, want zerolog.Ctx style", diag.Message, msg)
+			}
+		}
+	}
+}
+
+// setFlag sets an analyzer flag for the duration of the test.
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+	f := zerologlintctx.Analyzer.Flags.Lookup(name)
+	if f == nil {
+		t.Fatalf("unknown flag %q", name)
+	}
+	prev := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := f.Value.Set(prev); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestPrintFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	// Tests that Print, Printf and Println are rewritten into Event chains
+	analysistest.RunWithSuggestedFixes(t, testdata, zerologlintctx.Analyzer, "printfix")
+}
+
+func TestPrintLevel(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "print-level", "info")
+	analysistest.RunWithSuggestedFixes(t, testdata, zerologlintctx.Analyzer, "printlevel")
+}
+
+func TestRootCtxFuncs(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "root-ctx-funcs", "rootctx.Serve, rootctx.Server.Start")
+	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "rootctx")
+}
+
+func TestCtxTypes(t *testing.T) {
+	testdata := analysistest.TestData()
+	t.Run("all", func(t *testing.T) {
+		setFlag(t, "ctx-types", "all")
+		analysistest.Run(t, testdata, zerologlintctx.Analyzer, "ctxtypes/all")
+	})
+	t.Run("exact", func(t *testing.T) {
+		setFlag(t, "ctx-types", "exact")
+		analysistest.Run(t, testdata, zerologlintctx.Analyzer, "ctxtypes/exact")
+	})
+}
+
+func TestCtxAccessors(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "ctx-accessors", "accessors.Command.Context, accessors.Stream.Context, accessors.Job.Ctx")
+	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "accessors")
+}
+
+func TestCtxFields(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "ctx-fields", "true")
+	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "ctxfields")
+}
+
+func TestProviders(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "providers", "providers.Event, providers.Registry.Logger")
+	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "providers")
+}
+
+func TestZerologPkgs(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "zerolog-pkgs", "github.com/ourorg/zerolog")
+	setFlag(t, "zerolog-log-pkgs", "forks/logging")
+	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "forks")
+}
+
+func TestStrict(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "strict", "true")
+	analysistest.RunWithSuggestedFixes(t, testdata, zerologlintctx.Analyzer, "strict")
+}
+
+func TestHookAware(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "hook-aware", "true")
+	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "hooks/...")
+}
+
+func TestPreferCtxLogger(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "prefer-ctx-logger", "true")
+	analysistest.RunWithSuggestedFixes(t, testdata, zerologlintctx.Analyzer, "ctxlogger")
+}
+
+func TestStdlibLog(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "stdlib-log", "true")
+	setFlag(t, "stdlib-log-allow", "log.Fatal")
+	setFlag(t, "stdlib-log-deny", "fmt.Fprintln")
+	analysistest.RunWithSuggestedFixes(t, testdata, zerologlintctx.Analyzer, "stdliblog")
+}
+
+func TestGoroutineDetached(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "goroutine-ctx", "detached")
+	setFlag(t, "async-launchers", "goroutines/pool.Pool.Submit")
+	// No golden files: fixes passing the launching function's context are
+	// not offered
+	analysistest.RunWithSuggestedFixes(t, testdata, zerologlintctx.Analyzer, "goroutines/detached")
+}
+
+func TestGoroutineExempt(t *testing.T) {
+	testdata := analysistest.TestData()
+	setFlag(t, "goroutine-ctx", "exempt")
+	setFlag(t, "async-launchers", "goroutines/pool.Pool.Submit")
+	analysistest.Run(t, testdata, zerologlintctx.Analyzer, "goroutines/exempt")
+}
@@ -18,6 +18,7 @@
 //	│   │  RunSSA()                                                       │   │
 //	│   │    │                                                            │   │
 //	│   │    ├── Build function context map                               │   │
+//	│   │    ├── Summarize helpers and export facts                       │   │
 //	│   │    ├── Skip excluded files                                      │   │
 //	│   │    ├── Run SSA analysis via ssa.Checker                         │   │
 //	│   │    └── Report unused ignore directives                          │   │
@@ -36,8 +37,10 @@ import (
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
 
+	"github.com/mpyw/zerologlintctx/internal/config"
 	"github.com/mpyw/zerologlintctx/internal/directive"
 	ssautil "github.com/mpyw/zerologlintctx/internal/ssa"
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
 )
 
 // RunSSA performs SSA-based analysis for zerolog context propagation.
@@ -51,16 +54,54 @@ import (
 //   - Event.Ctx(ctx): Sets context on the current event
 //   - Context.Ctx(ctx): Sets default context for the derived logger
 //   - zerolog.Ctx(ctx): Returns a logger from context (already has ctx)
+//
+// ssaInfo is nil for packages that cannot reach zerolog (see BuildSSA);
+// only directive bookkeeping is done for them.
 func RunSSA(
 	pass *analysis.Pass,
 	ssaInfo *buildssa.SSA,
 	ignoreMaps map[string]directive.IgnoreMap,
 	skipFiles map[string]bool,
 	isContextType func(types.Type) bool,
+	pkgs *typeutil.Packages,
+	cfg *config.Config,
+) {
+	if ssaInfo != nil {
+		runChecks(pass, ssaInfo, ignoreMaps, skipFiles, isContextType, pkgs, cfg)
+	}
+
+	// Report unused ignore directives
+	for _, ignoreMap := range ignoreMaps {
+		if ignoreMap == nil {
+			continue
+		}
+		for _, pos := range ignoreMap.GetUnusedIgnores() {
+			pass.Reportf(pos, "unused zerologlintctx:ignore directive")
+		}
+	}
+}
+
+// runChecks summarizes helper functions and checks every function that has a
+// context available.
+func runChecks(
+	pass *analysis.Pass,
+	ssaInfo *buildssa.SSA,
+	ignoreMaps map[string]directive.IgnoreMap,
+	skipFiles map[string]bool,
+	isContextType func(types.Type) bool,
+	pkgs *typeutil.Packages,
+	cfg *config.Config,
 ) {
-	funcCtxNames := buildFunctionContextMap(ssaInfo, isContextType)
+	funcCtxNames := buildFunctionContextMap(ssaInfo, isContextType, cfg)
+
+	// Summarize helper functions and export facts for dependent packages
+	summaries := ssautil.NewSummaries(pass, ssaInfo.Pkg, ssaInfo.SrcFuncs, funcCtxNames, pkgs, cfg)
+	summaries.ExportFacts(ssaInfo.SrcFuncs)
 
-	for fn, ctxName := range funcCtxNames {
+	// Functions without a context are checked too: contexts created in
+	// their body are sources (see ssa.Checker.CheckFunction)
+	for _, fn := range ssaInfo.SrcFuncs {
+		ctxName := funcCtxNames[fn]
 		pos := fn.Pos()
 		if !pos.IsValid() {
 			continue
@@ -71,19 +112,9 @@ func RunSSA(
 		}
 		ignoreMap := ignoreMaps[filename]
 
-		chk := ssautil.NewChecker(pass, ctxName, ignoreMap)
+		chk := ssautil.NewChecker(pass, ctxName, ignoreMap, summaries, pkgs, cfg)
 		chk.CheckFunction(fn)
 	}
-
-	// Report unused ignore directives
-	for _, ignoreMap := range ignoreMaps {
-		if ignoreMap == nil {
-			continue
-		}
-		for _, pos := range ignoreMap.GetUnusedIgnores() {
-			pass.Reportf(pos, "unused zerologlintctx:ignore directive")
-		}
-	}
 }
 
 // =============================================================================
@@ -96,7 +127,8 @@ func RunSSA(
 // The algorithm works in two passes:
 //
 //	Pass 1: Find functions with direct context.Context parameters
-//	Pass 2: Propagate context to nested closures (iterate until stable)
+//	Pass 2: Propagate context to nested closures (iterate until stable);
+//	        with -goroutine-ctx=exempt, not to goroutine closures
 //
 // Example: Context propagation to closures
 //
@@ -108,12 +140,16 @@ func RunSSA(
 func buildFunctionContextMap(
 	ssaInfo *buildssa.SSA,
 	isContextType func(types.Type) bool,
+	cfg *config.Config,
 ) map[*ssa.Function]string {
 	funcCtx := make(map[*ssa.Function]string)
+	if cfg == nil {
+		cfg = config.Default()
+	}
 
 	// First pass: find direct context parameters
 	for _, fn := range ssaInfo.SrcFuncs {
-		if name := findContextParamName(fn, isContextType); name != "" {
+		if name := findContextParamName(fn, isContextType, cfg); name != "" {
 			funcCtx[fn] = name
 		}
 	}
@@ -125,6 +161,9 @@ func buildFunctionContextMap(
 			if _, hasCtx := funcCtx[fn]; hasCtx {
 				continue
 			}
+			if cfg.GoroutineCtx == config.GoroutineExempt && ssautil.LaunchedAsync(fn, cfg.AsyncLaunchers) {
+				continue
+			}
 			if fn.Parent() != nil {
 				if parentCtxName, ok := funcCtx[fn.Parent()]; ok {
 					funcCtx[fn] = parentCtxName
@@ -141,18 +180,87 @@ func buildFunctionContextMap(
 }
 
 // findContextParamName finds the name of the context.Context parameter in function signature.
-func findContextParamName(fn *ssa.Function, isContextType func(types.Type) bool) string {
+//
+// With several context parameters, the first one the function actually uses
+// is preferred, falling back to the first one. A blank parameter is only
+// chosen when there is no named one, so that the function is still checked
+// (the diagnostics then carry no suggested fix); unnamed ones are skipped.
+//
+// Functions without a context parameter may receive a value with a context
+// accessor method (*http.Request, or -ctx-accessors), possibly as their
+// receiver. The context is then the accessor call:
+//
+//	func handler(w http.ResponseWriter, r *http.Request)   →  "r.Context()"
+//	func run(cmd *cobra.Command, args []string)            →  "cmd.Context()"
+//	func (j *Job) Run()                                    →  "j.Ctx()"
+//
+// With -ctx-fields, a context.Context field of the receiver or of a struct
+// parameter is used last:
+//
+//	func (h *handler) serve()                              →  "h.ctx"
+func findContextParamName(fn *ssa.Function, isContextType func(types.Type) bool, cfg *config.Config) string {
 	if fn.Signature == nil {
 		return ""
 	}
-	params := fn.Signature.Params()
-	if params == nil {
-		return ""
+	params := fn.Params
+	if fn.Signature.Recv() != nil && len(params) > 0 {
+		params = params[1:]
+	}
+	if param := pickParam(params, isContextType); param != nil {
+		return param.Object().Name()
 	}
-	for param := range params.Variables() {
-		if isContextType(param.Type()) {
-			return param.Name()
+	for _, param := range params {
+		if obj := param.Object(); obj != nil && obj.Name() == "_" && isContextType(param.Type()) {
+			return "_"
 		}
 	}
+	hasAccessor := func(t types.Type) bool {
+		return typeutil.CtxAccessor(t, cfg.CtxAccessors) != ""
+	}
+	if param := pickParam(fn.Params, hasAccessor); param != nil {
+		return param.Object().Name() + "." + typeutil.CtxAccessor(param.Type(), cfg.CtxAccessors) + "()"
+	}
+	if !cfg.CtxFields || fn.Pkg == nil {
+		return ""
+	}
+	hasField := func(t types.Type) bool {
+		return typeutil.CtxField(t, fn.Pkg.Pkg) != ""
+	}
+	if param := pickParam(fn.Params, hasField); param != nil {
+		return param.Object().Name() + "." + typeutil.CtxField(param.Type(), fn.Pkg.Pkg)
+	}
 	return ""
 }
+
+// pickParam returns the first used named parameter matching the type
+// predicate, or the first one if none is used.
+func pickParam(params []*ssa.Parameter, match func(types.Type) bool) *ssa.Parameter {
+	var first *ssa.Parameter
+	for _, param := range params {
+		obj := param.Object()
+		if obj == nil || obj.Name() == "" || obj.Name() == "_" || !match(param.Type()) {
+			continue
+		}
+		if isUsed(param) {
+			return param
+		}
+		if first == nil {
+			first = param
+		}
+	}
+	return first
+}
+
+// isUsed returns true if v is referenced by any instruction other than debug info.
+func isUsed(v ssa.Value) bool {
+	refs := v.Referrers()
+	if refs == nil {
+		return false
+	}
+	for _, ref := range *refs {
+		if _, ok := ref.(*ssa.DebugRef); !ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// BuildSSA constructs the SSA form of the package under analysis, in the same
+// shape as buildssa.Analyzer.
+//
+// Requiring buildssa.Analyzer builds SSA for every package the driver runs
+// the analyzer on. Once the analyzer exports facts, that is every dependency
+// of the analyzed packages, including the whole standard library, whose SSA
+// construction may also fail on syntax newer than golang.org/x/tools. Instead,
+// SSA is only built for packages that can reach zerolog; for all others
+// BuildSSA returns nil, since they can neither log nor produce useful facts.
+func BuildSSA(pass *analysis.Pass, pkgs *typeutil.Packages) *buildssa.SSA {
+	if !reachesZerolog(pass.Pkg, pkgs, make(map[*types.Package]bool)) {
+		return nil
+	}
+
+	prog := ssa.NewProgram(pass.Fset, 0)
+
+	// Create SSA packages for direct imports.
+	for _, p := range pass.Pkg.Imports() {
+		prog.CreatePackage(p, nil, nil, true)
+	}
+
+	// Create and build the primary package.
+	ssapkg := prog.CreatePackage(pass.Pkg, pass.Files, pass.TypesInfo, false)
+	ssapkg.Build()
+
+	// Collect source functions, including literals, in source order.
+	var funcs []*ssa.Function
+	var addAnons func(f *ssa.Function)
+	addAnons = func(f *ssa.Function) {
+		funcs = append(funcs, f)
+		for _, anon := range f.AnonFuncs {
+			addAnons(anon)
+		}
+	}
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			obj, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			if f := prog.FuncValue(obj); f != nil {
+				addAnons(f)
+			}
+		}
+	}
+
+	return &buildssa.SSA{Pkg: ssapkg, SrcFuncs: funcs}
+}
+
+// reachesZerolog reports whether pkg is, or transitively imports, a zerolog package.
+func reachesZerolog(pkg *types.Package, pkgs *typeutil.Packages, seen map[*types.Package]bool) bool {
+	if seen[pkg] {
+		return false
+	}
+	seen[pkg] = true
+
+	if pkgs.IsZerologPackage(pkg.Path()) {
+		return true
+	}
+	for _, imp := range pkg.Imports() {
+		if reachesZerolog(imp, pkgs, seen) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,322 @@
+// Package config holds the analyzer settings populated from command-line flags.
+//
+// Flags are registered on the analyzer's FlagSet, so they are available with
+// every driver (singlechecker, go vet -vettool, golangci-lint plugins):
+//
+//	zerologlintctx -fix-style=zerolog-ctx ./...
+package config
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Config is the analyzer configuration.
+type Config struct {
+	// FixStyle selects which suggested fix for a missing context comes first,
+	// i.e. the one applied by -fix.
+	FixStyle FixStyle
+
+	// PrintLevel is the level of the Event chain that direct logging calls
+	// (Print, Printf, Println) are rewritten to.
+	PrintLevel Level
+
+	// RootCtxFuncs lists entry-point functions where passing a fresh root
+	// context (context.Background(), context.TODO()) to .Ctx() is intended.
+	RootCtxFuncs List
+
+	// CtxTypes selects which parameter types are accepted as the context
+	// source of a function.
+	CtxTypes CtxTypes
+
+	// CtxAccessors lists methods returning the context of their receiver,
+	// as "pkgpath.Type.Method". Functions receiving such a value have a
+	// context, spelled as the accessor call (e.g. cmd.Context()).
+	CtxAccessors List
+
+	// CtxFields makes context.Context fields of the receiver or of struct
+	// parameters a context source (e.g. h.ctx), for functions without one.
+	CtxFields bool
+
+	// Providers lists functions whose returned Logger or Event carries a
+	// context, like zerolog.Ctx, as "pkgpath.Func" or "pkgpath.Type.Method".
+	Providers List
+
+	// ZerologPkgs lists forks of github.com/rs/zerolog (e.g.
+	// github.com/ourorg/zerolog); their log and hlog subpackages are
+	// recognized as well.
+	ZerologPkgs List
+
+	// ZerologLogPkgs lists extra packages shaped like zerolog/log, wrapping
+	// a global logger (Ctx, Info, Print, ...).
+	ZerologLogPkgs List
+
+	// Strict makes only Event.Ctx and Context.Ctx satisfy the rule. Loggers
+	// from zerolog.Ctx(ctx) attach the context to their events only if they
+	// were built with Context.Ctx, so they are traced like any other logger,
+	// and Logger.WithContext is checked to store such loggers.
+	Strict bool
+
+	// HookAware requires a context only on chains whose logger has a hook
+	// reading Event.GetCtx attached, e.g. for trace ID injection. Loggers of
+	// unknown origin (parameters, fields, log.Logger not hooked in the
+	// package) may be hooked elsewhere, so they still need one.
+	HookAware bool
+
+	// PreferCtxLogger reports chains in functions with a context that are
+	// rooted at a package-level logger, a zerolog/log function or
+	// zerolog.New/Nop instead of the logger of the context.
+	PreferCtxLogger bool
+
+	// StdlibLog reports logging through the standard library (log, *log.Logger
+	// and the non-Context functions of log/slog) in functions with a context.
+	StdlibLog bool
+
+	// StdlibLogAllow lists functions, as "pkgpath.Func" or
+	// "pkgpath.Type.Method", or whole package paths exempt from StdlibLog.
+	StdlibLogAllow List
+
+	// StdlibLogDeny lists further functions, or whole package paths, reported
+	// by StdlibLog (e.g. fmt.Fprintln).
+	StdlibLogDeny List
+
+	// GoroutineCtx is the policy for the context of closures started by go
+	// statements and async launchers, which otherwise inherit the context of
+	// the enclosing function.
+	GoroutineCtx GoroutinePolicy
+
+	// AsyncLaunchers lists further functions running their function
+	// arguments in a new goroutine, as "pkgpath.Func" or
+	// "pkgpath.Type.Method" (errgroup.Group.Go and TryGo are always included).
+	AsyncLaunchers List
+}
+
+// Default returns the default configuration.
+func Default() *Config {
+	return &Config{
+		FixStyle:     FixStyleCtx,
+		PrintLevel:   LevelDebug,
+		CtxTypes:     CtxTypesEmbedded,
+		GoroutineCtx: GoroutineAny,
+	}
+}
+
+// RegisterFlags registers the configuration flags on fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Var(&c.FixStyle, "fix-style", "preferred fix for a missing context: "+
+		"ctx (insert .Ctx(ctx) after the level call) or "+
+		"zerolog-ctx (rewrite the logger to zerolog.Ctx(ctx))")
+	fs.Var(&c.PrintLevel, "print-level", "level of the Event chain suggested for Print, Printf and Println: "+
+		"trace, debug, info, warn or error")
+	fs.Var(&c.RootCtxFuncs, "root-ctx-funcs", "comma-separated functions allowed to log with a root context, "+
+		"as pkgpath.Func or pkgpath.Type.Method")
+	fs.Var(&c.CtxTypes, "ctx-types", "parameter types accepted as a context: "+
+		"exact (context.Context only), "+
+		"embedded (also interfaces and structs embedding context.Context) or "+
+		"all (any type implementing context.Context)")
+	fs.Var(&c.CtxAccessors, "ctx-accessors", "comma-separated methods returning the context of their receiver, "+
+		"as pkgpath.Type.Method (net/http.Request.Context is always included)")
+	fs.BoolVar(&c.CtxFields, "ctx-fields", c.CtxFields, "treat context.Context fields of the receiver "+
+		"or struct parameters as the context of functions without one")
+	fs.Var(&c.Providers, "providers", "comma-separated functions returning a Logger or Event with context, "+
+		"as pkgpath.Func or pkgpath.Type.Method (see also //zerologlintctx:provider)")
+	fs.Var(&c.ZerologPkgs, "zerolog-pkgs", "comma-separated import paths of zerolog forks, "+
+		"checked in addition to github.com/rs/zerolog")
+	fs.Var(&c.ZerologLogPkgs, "zerolog-log-pkgs", "comma-separated import paths of packages "+
+		"shaped like zerolog/log, wrapping a global logger")
+	fs.BoolVar(&c.Strict, "strict", c.Strict, "accept only Event.Ctx and Context.Ctx, not loggers from zerolog.Ctx(ctx), "+
+		"and report Logger.WithContext storing a logger without Context.Ctx")
+	fs.BoolVar(&c.HookAware, "hook-aware", c.HookAware, "require a context only on chains whose logger "+
+		"has a hook calling Event.GetCtx attached")
+	fs.BoolVar(&c.PreferCtxLogger, "prefer-ctx-logger", c.PreferCtxLogger, "report chains rooted at a global "+
+		"or new logger in functions with a context, suggesting log.Ctx(ctx)")
+	fs.BoolVar(&c.StdlibLog, "stdlib-log", c.StdlibLog, "report standard library logging "+
+		"(log, log/slog without Context) in functions with a context")
+	fs.Var(&c.StdlibLogAllow, "stdlib-log-allow", "comma-separated functions (pkgpath.Func, "+
+		"pkgpath.Type.Method) or package paths not reported by -stdlib-log")
+	fs.Var(&c.StdlibLogDeny, "stdlib-log-deny", "comma-separated functions (pkgpath.Func, "+
+		"pkgpath.Type.Method) or package paths also reported by -stdlib-log, e.g. fmt.Fprintln")
+	fs.Var(&c.GoroutineCtx, "goroutine-ctx", "context policy for closures started by go statements and async launchers: "+
+		"any (the enclosing function's context is fine), "+
+		"detached (require context.WithoutCancel or a context derived in the goroutine) or "+
+		"exempt (the enclosing function's context is not required)")
+	fs.Var(&c.AsyncLaunchers, "async-launchers", "comma-separated functions running their function arguments "+
+		"in a new goroutine, as pkgpath.Func or pkgpath.Type.Method (errgroup.Group.Go and TryGo are always included)")
+}
+
+// =============================================================================
+// Fix Style
+// =============================================================================
+
+// FixStyle is the preferred style of suggested fixes.
+type FixStyle string
+
+const (
+	// FixStyleCtx inserts .Ctx(ctx) right after the level call:
+	//
+	//	logger.Info().Msg("x")  →  logger.Info().Ctx(ctx).Msg("x")
+	FixStyleCtx FixStyle = "ctx"
+
+	// FixStyleZerologCtx rewrites the logger source to zerolog.Ctx(ctx):
+	//
+	//	logger.Info().Msg("x")  →  zerolog.Ctx(ctx).Info().Msg("x")
+	FixStyleZerologCtx FixStyle = "zerolog-ctx"
+)
+
+func (s *FixStyle) String() string {
+	return string(*s)
+}
+
+// Set implements flag.Value.
+func (s *FixStyle) Set(v string) error {
+	switch FixStyle(v) {
+	case FixStyleCtx, FixStyleZerologCtx:
+		*s = FixStyle(v)
+		return nil
+	}
+	return fmt.Errorf("invalid fix style %q (want %q or %q)", v, FixStyleCtx, FixStyleZerologCtx)
+}
+
+// =============================================================================
+// Context Types
+// =============================================================================
+
+// CtxTypes selects the parameter types a function's context is taken from.
+type CtxTypes string
+
+const (
+	// CtxTypesExact accepts context.Context only.
+	CtxTypesExact CtxTypes = "exact"
+
+	// CtxTypesEmbedded also accepts interfaces, type parameters and structs
+	// embedding context.Context:
+	//
+	//	type RequestCtx interface { context.Context; UserID() string }
+	//	func f[C context.Context](c C)
+	CtxTypesEmbedded CtxTypes = "embedded"
+
+	// CtxTypesAll accepts any type implementing context.Context, including
+	// framework types such as *gin.Context.
+	CtxTypesAll CtxTypes = "all"
+)
+
+func (m *CtxTypes) String() string {
+	return string(*m)
+}
+
+// Set implements flag.Value.
+func (m *CtxTypes) Set(v string) error {
+	switch CtxTypes(v) {
+	case CtxTypesExact, CtxTypesEmbedded, CtxTypesAll:
+		*m = CtxTypes(v)
+		return nil
+	}
+	return fmt.Errorf("invalid context types %q (want exact, embedded or all)", v)
+}
+
+// =============================================================================
+// Goroutine Policy
+// =============================================================================
+
+// GoroutinePolicy selects how the context of goroutine closures is checked.
+type GoroutinePolicy string
+
+const (
+	// GoroutineAny accepts the context of the enclosing function:
+	//
+	//	go func() { log.Info().Ctx(ctx).Msg("x") }()   // OK
+	GoroutineAny GoroutinePolicy = "any"
+
+	// GoroutineDetached requires a context that outlives the enclosing
+	// function: context.WithoutCancel, or one derived in the goroutine.
+	//
+	//	go func() { log.Info().Ctx(ctx).Msg("x") }()                         // reported
+	//	go func() { log.Info().Ctx(context.WithoutCancel(ctx)).Msg("x") }()  // OK
+	GoroutineDetached GoroutinePolicy = "detached"
+
+	// GoroutineExempt does not give goroutine closures the context of the
+	// enclosing function; only contexts created in them are required.
+	GoroutineExempt GoroutinePolicy = "exempt"
+)
+
+func (p *GoroutinePolicy) String() string {
+	return string(*p)
+}
+
+// Set implements flag.Value.
+func (p *GoroutinePolicy) Set(v string) error {
+	switch GoroutinePolicy(v) {
+	case GoroutineAny, GoroutineDetached, GoroutineExempt:
+		*p = GoroutinePolicy(v)
+		return nil
+	}
+	return fmt.Errorf("invalid goroutine policy %q (want any, detached or exempt)", v)
+}
+
+// =============================================================================
+// Level
+// =============================================================================
+
+// Level is a zerolog level, named after the Logger method creating its Event.
+type Level string
+
+// Levels accepted by -print-level. Debug matches what zerolog's own Print
+// methods log at.
+const (
+	LevelTrace Level = "trace"
+	LevelDebug Level = "debug"
+	LevelInfo  Level = "info"
+	LevelWarn  Level = "warn"
+	LevelError Level = "error"
+)
+
+func (l *Level) String() string {
+	return string(*l)
+}
+
+// Set implements flag.Value.
+func (l *Level) Set(v string) error {
+	switch Level(v) {
+	case LevelTrace, LevelDebug, LevelInfo, LevelWarn, LevelError:
+		*l = Level(v)
+		return nil
+	}
+	return fmt.Errorf("invalid level %q (want trace, debug, info, warn or error)", v)
+}
+
+// Method returns the name of the Logger method for the level (e.g. "Debug").
+func (l Level) Method() string {
+	if l == "" {
+		return ""
+	}
+	return strings.ToUpper(string(l[:1])) + string(l[1:])
+}
+
+// =============================================================================
+// List
+// =============================================================================
+
+// List is a comma-separated list flag. Setting it replaces previous values.
+type List []string
+
+func (l *List) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set implements flag.Value.
+func (l *List) Set(v string) error {
+	*l = nil
+	for item := range strings.SplitSeq(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the list contains s.
+func (l List) Contains(s string) bool {
+	return slices.Contains(l, s)
+}
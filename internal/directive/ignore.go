@@ -2,12 +2,14 @@
 //
 // # Supported Directives
 //
-// The package recognizes the following comment directive:
+// The package recognizes the following comment directives:
 //
 //	//zerologlintctx:ignore
+//	//zerologlintctx:provider
 //
-// This directive can be placed on the same line or the line before the code
-// to suppress warnings.
+// The ignore directive can be placed on the same line or the line before the
+// code to suppress warnings. The provider directive marks a function whose
+// results carry a context, like zerolog.Ctx (see IsProvider).
 //
 // # Usage Examples
 //
@@ -0,0 +1,33 @@
+package directive
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// IsProvider returns true if a function declaration is marked as a provider
+// of loggers or events that carry a context:
+//
+//	//zerologlintctx:provider
+//	func FromContext(ctx context.Context) zerolog.Logger { ... }
+//
+// The directive must appear in the doc comment of the declaration.
+func IsProvider(decl *ast.FuncDecl) bool {
+	if decl.Doc == nil {
+		return false
+	}
+	for _, c := range decl.Doc.List {
+		if isProviderComment(c.Text) {
+			return true
+		}
+	}
+	return false
+}
+
+// isProviderComment checks if a comment is a provider directive.
+// Supports both "//zerologlintctx:provider" and "// zerologlintctx:provider".
+func isProviderComment(text string) bool {
+	text = strings.TrimPrefix(text, "//")
+	text = strings.TrimSpace(text)
+	return text == "zerologlintctx:provider"
+}
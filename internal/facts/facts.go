@@ -0,0 +1,160 @@
+// Package facts defines the analysis facts exported by zerologlintctx.
+//
+// Facts carry per-function summaries across package boundaries so that
+// the SSA tracer can see through helper functions defined elsewhere:
+//
+//	package applog                         package handler
+//	─────────────                          ───────────────
+//	func NewEvent(ctx, l) *Event {         func h(ctx context.Context) {
+//	    return l.Info().Ctx(ctx)    ──▶        applog.NewEvent(ctx, l).Msg("ok")
+//	}                               fact   }
+//	ReturnFact{WithCtx}                    (no report: fact says ctx is set)
+//
+// Functions without a context parameter are never checked themselves, so
+// events they log on behalf of their callers are reported at the call site:
+//
+//	func Finish(e *Event) {                func h(ctx context.Context) {
+//	    e.Msg("done")               ──▶        applog.Finish(l.Info())  ← report
+//	}                               fact   }
+//	ConsumeFact{0}
+//
+// Functions marked with //zerologlintctx:provider carry a ProviderFact: their
+// results count as having a context, like those of zerolog.Ctx.
+package facts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReturnCtx describes whether a zerolog-typed result carries a context.
+type ReturnCtx uint8
+
+const (
+	// ReturnUnknown means the result is not a zerolog type or could not be summarized.
+	ReturnUnknown ReturnCtx = iota
+	// ReturnWithCtx means every return path yields a value with context set.
+	ReturnWithCtx
+	// ReturnWithoutCtx means at least one return path yields a value without context.
+	ReturnWithoutCtx
+	// ReturnFromParams means the result carries a context iff all listed parameters do.
+	ReturnFromParams
+)
+
+func (r ReturnCtx) String() string {
+	switch r {
+	case ReturnWithCtx:
+		return "with-ctx"
+	case ReturnWithoutCtx:
+		return "without-ctx"
+	case ReturnFromParams:
+		return "from-params"
+	}
+	return "unknown"
+}
+
+// Result summarizes a single result of a function.
+type Result struct {
+	Ctx    ReturnCtx // Context status of the result
+	Params []int     // Parameter indices the result depends on (ReturnFromParams only)
+}
+
+// ReturnFact is attached to functions returning *zerolog.Event, zerolog.Logger
+// or zerolog.Context. Results is indexed by result position; non-zerolog
+// results are recorded as ReturnUnknown.
+type ReturnFact struct {
+	Results []Result
+}
+
+// AFact implements analysis.Fact.
+func (*ReturnFact) AFact() {}
+
+func (f *ReturnFact) String() string {
+	parts := make([]string, len(f.Results))
+	for i, r := range f.Results {
+		if r.Ctx == ReturnFromParams {
+			parts[i] = fmt.Sprintf("%s%v", r.Ctx, r.Params)
+		} else {
+			parts[i] = r.Ctx.String()
+		}
+	}
+	return "returns(" + strings.Join(parts, ", ") + ")"
+}
+
+// Result returns the summary of the i-th result, or a zero Result if out of range.
+func (f *ReturnFact) Result(i int) Result {
+	if f == nil || i < 0 || i >= len(f.Results) {
+		return Result{}
+	}
+	return f.Results[i]
+}
+
+// ParamPath locates a zerolog value reachable from a parameter, either the
+// parameter itself or a (possibly nested) struct field of it. Param counts
+// the receiver, if any, as parameter 0. Fields holds struct field indices,
+// following pointers implicitly.
+//
+//	func (h *holder) log() { h.e.Msg("x") }   → ParamPath{Param: 0, Fields: [0]}
+type ParamPath struct {
+	Param  int
+	Fields []int
+}
+
+func (p ParamPath) String() string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(p.Param))
+	for _, f := range p.Fields {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(f))
+	}
+	return b.String()
+}
+
+// ConsumeFact is attached to functions without a context parameter that log
+// an Event or Logger passed in by their caller without setting a context.
+// The call site must pass values that already carry a context.
+type ConsumeFact struct {
+	Paths []ParamPath
+}
+
+// AFact implements analysis.Fact.
+func (*ConsumeFact) AFact() {}
+
+func (f *ConsumeFact) String() string {
+	parts := make([]string, len(f.Paths))
+	for i, p := range f.Paths {
+		parts[i] = p.String()
+	}
+	return "consumes(" + strings.Join(parts, ", ") + ")"
+}
+
+// ProviderFact is attached to exported functions marked with
+// //zerologlintctx:provider. Loggers and events they return carry a context.
+type ProviderFact struct{}
+
+// AFact implements analysis.Fact.
+func (*ProviderFact) AFact() {}
+
+func (*ProviderFact) String() string { return "provider" }
+
+// ReadsCtxFact is attached to functions calling Event.GetCtx, directly or
+// through other functions: typically Run methods of zerolog.Hook
+// implementations and functions used as zerolog.HookFunc. Only computed
+// with -hook-aware.
+type ReadsCtxFact struct{}
+
+// AFact implements analysis.Fact.
+func (*ReadsCtxFact) AFact() {}
+
+func (*ReadsCtxFact) String() string { return "reads-ctx" }
+
+// HookedFact is attached to functions returning, and package-level variables
+// holding, a Logger (or Event) with a context-reading hook attached.
+// Only computed with -hook-aware.
+type HookedFact struct{}
+
+// AFact implements analysis.Fact.
+func (*HookedFact) AFact() {}
+
+func (*HookedFact) String() string { return "hooked" }
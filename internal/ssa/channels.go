@@ -0,0 +1,52 @@
+package ssa
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// =============================================================================
+// Channel Flow
+// =============================================================================
+
+// traceReceive traces a value received from the channel ch: it has a
+// context if every value sent on a channel that may alias ch has one.
+//
+//	ch := make(chan *zerolog.Event, 1)
+//	ch <- logger.Info().Ctx(ctx)
+//	e := <-ch           // traced to the send
+//	e.Msg("x")
+//
+// Channels are followed within the package (see aliasFlow). Receives from
+// channels of unknown origin (parameters, function results) or passed where
+// the senders cannot be found have no context.
+func (c *Checker) traceReceive(ch ssa.Value, visited map[ssa.Value]bool, t tracerType) bool {
+	if visited[ch] {
+		return false
+	}
+	visited[ch] = true
+
+	sent, ok := newAliasFlow(c.summaries).writes(ch)
+	if !ok || len(sent) == 0 {
+		return false
+	}
+	return c.traceAllStoredValues(sent, visited, t)
+}
+
+// traceSelectRecv traces the value received by a select case: the Extract of
+// index i >= 2 of a blocking or non-blocking select is the value of its
+// (i-2)-th receive.
+func (c *Checker) traceSelectRecv(sel *ssa.Select, index int, visited map[ssa.Value]bool, t tracerType) bool {
+	recv := index - 2
+	for _, state := range sel.States {
+		if state.Dir != types.RecvOnly {
+			continue
+		}
+		if recv == 0 {
+			return c.traceReceive(state.Chan, visited, t)
+		}
+		recv--
+	}
+	return false
+}
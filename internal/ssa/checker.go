@@ -25,12 +25,15 @@
 package ssa
 
 import (
+	"fmt"
 	"go/token"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/ssa"
 
+	"github.com/mpyw/zerologlintctx/internal/config"
 	"github.com/mpyw/zerologlintctx/internal/directive"
+	"github.com/mpyw/zerologlintctx/internal/facts"
 	"github.com/mpyw/zerologlintctx/internal/typeutil"
 )
 
@@ -43,31 +46,87 @@ import (
 // For each terminator, it traces backwards to verify .Ctx(ctx) was called.
 type Checker struct {
 	pass      *analysis.Pass      // For reporting diagnostics
-	ctxName   string              // Context variable name (for error messages)
+	cfg       *config.Config      // Analyzer settings
+	pkgs      *typeutil.Packages  // Recognized zerolog packages
+	ctxName   string              // Context name at the current site (for error messages)
+	fnCtxName string              // Context name of the function
 	ignoreMap directive.IgnoreMap // Line-level ignore directives
 	reported  map[token.Pos]bool  // Deduplication: same position reported once
+
+	rootCtxAllowed bool                     // Function may pass root contexts (see provenance.go)
+	launchCtx      bool                     // Site's context is one a goroutine must not use (see goroutine.go)
+	escapeMode     bool                     // Only Context.Ctx binds a context (see escape.go)
+	rootMode       bool                     // Only global and new loggers are found (see ctxlogger.go)
+	roots          []string                 // Loggers reached in root mode
+	scopes         map[*ssa.Function]*scope // Derived contexts per function (see scope.go)
+	summaries      *Summaries               // Interprocedural function summaries
+
+	// Summary mode: set only while summarizing a function (see summary.go).
+	summaryOf *ssa.Function     // Function being summarized
+	hits      []facts.ParamPath // Parameters reached while tracing
 }
 
 // NewChecker creates a new checker for analyzing a function.
-func NewChecker(pass *analysis.Pass, ctxName string, ignoreMap directive.IgnoreMap) *Checker {
+func NewChecker(
+	pass *analysis.Pass,
+	ctxName string,
+	ignoreMap directive.IgnoreMap,
+	summaries *Summaries,
+	pkgs *typeutil.Packages,
+	cfg *config.Config,
+) *Checker {
 	return &Checker{
 		pass:      pass,
+		cfg:       cfg,
+		pkgs:      pkgs,
 		ctxName:   ctxName,
+		fnCtxName: ctxName,
+		scopes:    make(map[*ssa.Function]*scope),
 		ignoreMap: ignoreMap,
 		reported:  make(map[token.Pos]bool),
+		summaries: summaries,
 	}
 }
 
 // CheckFunction analyzes all instructions in a function.
 func (c *Checker) CheckFunction(fn *ssa.Function) {
+	c.rootCtxAllowed = c.isRootCtxAllowed(fn)
+	// Events never sent, used after sending or shared across goroutines are
+	// bugs whether or not a context is available
+	c.checkUnsentEvents(fn)
+	c.checkEventReuse(fn)
+	c.checkSharedEvents(fn)
 	for _, block := range fn.Blocks {
 		for _, instr := range block.Instrs {
+			// Messages and fixes name the most-derived context live here;
+			// in functions without a context, only sites a local one reaches
+			// are checked
+			c.ctxName = c.nameAt(fn, instr)
+			if c.ctxName == "" {
+				continue
+			}
+			c.launchCtx = c.launchCtxAt(fn, instr)
+
 			switch v := instr.(type) {
 			case *ssa.Call:
-				c.checkTerminatorCall(v)
+				// A chain off the context logger is missing .Ctx() as well;
+				// only the former is reported
+				if !c.checkPreferCtxLogger(v) {
+					c.checkTerminatorCall(v)
+				}
 				c.checkDirectLoggingCall(v)
+				c.checkCtxArgument(v)
+				c.checkStaleCtx(v)
+				c.checkWithContext(v)
+				c.checkGoroutineCtx(v)
 			case *ssa.Defer:
 				c.checkDeferredCall(v)
+				c.checkCtxArgument(v)
+			case *ssa.Store, *ssa.Send, *ssa.MapUpdate:
+				c.checkCtxLoggerEscape(v)
+			}
+			if call, ok := instr.(ssa.CallInstruction); ok {
+				c.checkConsumerCall(call)
 			}
 		}
 	}
@@ -89,16 +148,16 @@ func (c *Checker) checkDeferredCall(d *ssa.Defer) {
 
 	// Must be on zerolog.Event and return void (terminators: Msg, Msgf, MsgFunc, Send)
 	recv := d.Call.Signature().Recv()
-	if recv == nil || !typeutil.IsEvent(recv.Type()) || !typeutil.ReturnsVoid(callee) {
+	if recv == nil || !c.pkgs.IsEvent(recv.Type()) || !typeutil.ReturnsVoid(callee) {
 		return
 	}
 
 	// Trace back to find if context was set
-	if len(d.Call.Args) > 0 && c.eventChainHasCtx(d.Call.Args[0]) {
+	if len(d.Call.Args) == 0 || c.eventChainHasCtx(d.Call.Args[0]) || !c.needsCtx(d.Call.Args[0]) {
 		return
 	}
 
-	c.report(d.Pos(), "zerolog call chain missing .Ctx(%s)")
+	c.report(d.Pos(), "zerolog call chain missing .Ctx(%s)", c.ctxFixes(d.Call.Args[0])...)
 }
 
 // checkDeferredBoundMethodTerminator checks if a deferred bound method call is a terminator
@@ -114,16 +173,16 @@ func (c *Checker) checkDeferredBoundMethodTerminator(d *ssa.Defer, mc *ssa.MakeC
 		return
 	}
 	recvType := mc.Bindings[0].Type()
-	if !typeutil.IsEvent(recvType) {
+	if !c.pkgs.IsEvent(recvType) {
 		return
 	}
 
 	// Trace the receiver to find if context was set
-	if c.eventChainHasCtx(mc.Bindings[0]) {
+	if c.eventChainHasCtx(mc.Bindings[0]) || !c.needsCtx(mc.Bindings[0]) {
 		return
 	}
 
-	c.report(d.Pos(), "zerolog call chain missing .Ctx(%s)")
+	c.report(d.Pos(), "zerolog call chain missing .Ctx(%s)", c.ctxFixes(mc.Bindings[0])...)
 }
 
 // checkTerminatorCall checks if a terminator call (Msg, Msgf, MsgFunc, Send)
@@ -143,16 +202,16 @@ func (c *Checker) checkTerminatorCall(call *ssa.Call) {
 
 	// Must be on zerolog.Event and return void (terminators: Msg, Msgf, MsgFunc, Send)
 	recv := call.Call.Signature().Recv()
-	if recv == nil || !typeutil.IsEvent(recv.Type()) || !typeutil.ReturnsVoid(callee) {
+	if recv == nil || !c.pkgs.IsEvent(recv.Type()) || !typeutil.ReturnsVoid(callee) {
 		return
 	}
 
 	// Trace back to find if context was set
-	if len(call.Call.Args) > 0 && c.eventChainHasCtx(call.Call.Args[0]) {
+	if len(call.Call.Args) == 0 || c.eventChainHasCtx(call.Call.Args[0]) || !c.needsCtx(call.Call.Args[0]) {
 		return
 	}
 
-	c.report(call.Pos(), "zerolog call chain missing .Ctx(%s)")
+	c.report(call.Pos(), "zerolog call chain missing .Ctx(%s)", c.ctxFixes(call.Call.Args[0])...)
 }
 
 // checkBoundMethodTerminator checks if a bound method call (method value) is a terminator
@@ -180,16 +239,16 @@ func (c *Checker) checkBoundMethodTerminator(call *ssa.Call, mc *ssa.MakeClosure
 		return
 	}
 	recvType := mc.Bindings[0].Type()
-	if !typeutil.IsEvent(recvType) {
+	if !c.pkgs.IsEvent(recvType) {
 		return
 	}
 
 	// Trace the receiver to find if context was set
-	if c.eventChainHasCtx(mc.Bindings[0]) {
+	if c.eventChainHasCtx(mc.Bindings[0]) || !c.needsCtx(mc.Bindings[0]) {
 		return
 	}
 
-	c.report(call.Pos(), "zerolog call chain missing .Ctx(%s)")
+	c.report(call.Pos(), "zerolog call chain missing .Ctx(%s)", c.ctxFixes(mc.Bindings[0])...)
 }
 
 // checkDirectLoggingCall checks for direct logging calls that bypass the Event chain.
@@ -202,19 +261,84 @@ func (c *Checker) checkDirectLoggingCall(call *ssa.Call) {
 	recv := call.Call.Signature().Recv()
 
 	// Check for Logger.Print/Printf (method on Logger that returns void)
-	if typeutil.IsDirectLoggingMethod(callee, recv) {
-		c.report(call.Pos(), "zerolog direct logging bypasses context; use Event chain with .Ctx(%s)")
+	if c.pkgs.IsDirectLoggingMethod(callee, recv) && c.needsCtx(call) {
+		c.report(call.Pos(), "zerolog direct logging bypasses context; use Event chain with .Ctx(%s)",
+			c.printFixes(call.Pos(), callee.Name())...)
 		return
 	}
 
 	// Check for log.Print/log.Printf (package-level function that returns void)
-	if typeutil.IsDirectLoggingFunc(callee) {
-		c.report(call.Pos(), "zerolog direct logging bypasses context; use Event chain with .Ctx(%s)")
+	if c.pkgs.IsDirectLoggingFunc(callee) && c.needsCtx(call) {
+		c.report(call.Pos(), "zerolog direct logging bypasses context; use Event chain with .Ctx(%s)",
+			c.printFixes(call.Pos(), callee.Name())...)
+		return
+	}
+
+	// Check for the standard library's log and log/slog (see stdlib.go)
+	c.checkStdlibLogging(call, callee)
+}
+
+// checkWithContext checks, in strict mode, that loggers stored in a context
+// were built with Context.Ctx, so that events of zerolog.Ctx(ctx) carry it:
+//
+//	ctx = logger.WithContext(ctx)                           ← reported
+//	ctx = logger.With().Ctx(ctx).Logger().WithContext(ctx)  ← OK
+func (c *Checker) checkWithContext(call *ssa.Call) {
+	if !c.strict() {
+		return
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Name() != "WithContext" || len(call.Call.Args) == 0 {
+		return
+	}
+	recv := call.Call.Signature().Recv()
+	if recv == nil || !c.pkgs.IsLogger(recv.Type()) {
+		return
+	}
+	if c.traceValue(call.Call.Args[0], tracerLogger, make(map[ssa.Value]bool)) || !c.needsCtx(call.Call.Args[0]) {
 		return
 	}
+	c.report(call.Pos(), "zerolog logger stored by WithContext missing .With().Ctx(%s)", c.withContextFixes(call)...)
+}
+
+// strict returns true if only Event.Ctx and Context.Ctx attach a context
+// (see config.Config.Strict).
+func (c *Checker) strict() bool {
+	return c.cfg != nil && c.cfg.Strict
+}
+
+// checkConsumerCall checks calls to functions without a context that log
+// events passed in by the caller (see summarizeConsumes).
+//
+//	func finish(e *zerolog.Event) { e.Msg("done") }
+//
+//	func handler(ctx context.Context) {
+//	    finish(logger.Info())   ← reported here: finish cannot add ctx
+//	}
+func (c *Checker) checkConsumerCall(call ssa.CallInstruction) {
+	if c.consumerArgsHaveCtx(call.Common()) {
+		return
+	}
+	c.report(call.Pos(), "zerolog call chain missing .Ctx(%s) before passing to "+call.Common().StaticCallee().Name())
+}
+
+// report reports a diagnostic at pos, with the context variable name
+// substituted into format.
+func (c *Checker) report(pos token.Pos, format string, fixes ...analysis.SuggestedFix) {
+	if c.ctxName == "_" {
+		// A blank context parameter cannot be passed
+		fixes = nil
+	}
+	if c.launchCtx {
+		// Passing it is reported by checkGoroutineCtx
+		format += "; in a goroutine, use context.WithoutCancel(%[1]s) or derive one (-goroutine-ctx=detached)"
+		fixes = nil
+	}
+	c.reportMsg(pos, fmt.Sprintf(format, c.ctxName), fixes...)
 }
 
-func (c *Checker) report(pos token.Pos, format string) {
+// reportMsg reports a diagnostic at pos, unless already reported or ignored.
+func (c *Checker) reportMsg(pos token.Pos, msg string, fixes ...analysis.SuggestedFix) {
 	if c.reported[pos] {
 		return
 	}
@@ -225,7 +349,11 @@ func (c *Checker) report(pos token.Pos, format string) {
 		return
 	}
 
-	c.pass.Reportf(pos, format, c.ctxName)
+	c.pass.Report(analysis.Diagnostic{
+		Pos:            pos,
+		Message:        msg,
+		SuggestedFixes: fixes,
+	})
 }
 
 // eventChainHasCtx traces an Event value to check if .Ctx() was called.
@@ -0,0 +1,44 @@
+package ssa
+
+import (
+	"golang.org/x/tools/go/ssa"
+)
+
+// =============================================================================
+// Container Elements
+// =============================================================================
+
+// traceElement traces a value read from the slice, array or map container:
+// it has a context if every value written into a container that may alias
+// it has one.
+//
+//	events := []*zerolog.Event{logger.Info().Ctx(ctx)}
+//	events = append(events, logger.Warn().Ctx(ctx))
+//	events[i].Msg("x")          // traced to both writes
+//
+//	m := map[string]zerolog.Logger{}
+//	m["a"] = logger.With().Ctx(ctx).Logger()
+//	m["a"].Info().Msg("x")      // traced to the MapUpdate
+//
+// Writes are collected by aliasFlow: element stores (composite literals,
+// s[i] = v, the variadic arguments of append) and MapUpdate. Containers
+// nested in another one (m["a"][0]) are followed through it. Elements of
+// containers of unknown origin (parameters, function results), or passed
+// where writes cannot be found, have no context.
+func (c *Checker) traceElement(container ssa.Value, visited map[ssa.Value]bool, t tracerType) bool {
+	written, ok := newAliasFlow(c.summaries).writes(container)
+	if !ok || len(written) == 0 {
+		return false
+	}
+	return c.traceAllStoredValues(written, visited, t)
+}
+
+// traceRangeValue traces the value of a range loop over a map: the Extract
+// of index 2 of Next.
+func (c *Checker) traceRangeValue(next *ssa.Next, index int, visited map[ssa.Value]bool, t tracerType) bool {
+	rng, ok := next.Iter.(*ssa.Range)
+	if !ok || next.IsString || index != 2 {
+		return false
+	}
+	return c.traceElement(rng.X, visited, t)
+}
@@ -0,0 +1,237 @@
+package ssa
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Context Logger Preference
+// =============================================================================
+
+// fixMsgCtxLogger is the fix message for chains not using the context logger.
+const fixMsgCtxLogger = "Use log.Ctx(%s) as the logger"
+
+// checkPreferCtxLogger reports, with -prefer-ctx-logger, event chains in
+// functions with a context whose logger is not the one of the context:
+//
+//	func handler(ctx context.Context) {
+//	    log.Info().Msg("x")                      ← reported (log.Info)
+//	    log.Logger.Info().Ctx(ctx).Msg("x")      ← reported (log.Logger)
+//	    zerolog.New(w).Info().Msg("x")           ← reported (zerolog.New)
+//	    log.Ctx(ctx).Info().Msg("x")             ← OK
+//	}
+//
+// Adding .Ctx(ctx) to such chains is not enough: the fields attached to the
+// context logger by middleware are lost. The logger of each level call is
+// traced in root mode (see originRoot); only chains rooted at a
+// package-level logger, a zerolog/log function or zerolog.New/Nop on every
+// path are reported, at the level call. It returns true if the chain was
+// reported, so that its missing .Ctx() is not reported on top.
+func (c *Checker) checkPreferCtxLogger(call *ssa.Call) bool {
+	if c.cfg == nil || !c.cfg.PreferCtxLogger || c.fnCtxName == "" {
+		return false
+	}
+	ev := terminatedEvent(c.pkgs, &call.Call)
+	if ev == nil {
+		return false
+	}
+	origins, ok := c.eventOrigins(ev, make(map[ssa.Value]bool))
+	if !ok {
+		return false
+	}
+	reported := false
+	for _, origin := range origins {
+		root := c.originRoot(origin)
+		if root == "" {
+			continue
+		}
+		c.reportMsg(origin.Pos(),
+			fmt.Sprintf("zerolog chain rooted at %s instead of the context logger; use log.Ctx(%s)", root, c.ctxName),
+			c.ctxLoggerFixes(origin)...)
+		reported = true
+	}
+	return reported
+}
+
+// originRoot describes the logger a level call is made on, if it is a
+// global or new logger, or returns "".
+//
+// The receiver is traced with tracerLogger in root mode, where package-level
+// loggers, zerolog.New/Nop and zerolog/log functions are found instead of
+// contexts, and providers, parameters and helpers are not:
+//
+//	l := appLogger.With().Str("k", "v").Logger()
+//	l.Info()                      → "pkg.appLogger"
+//	zerolog.Ctx(ctx).Info()       → ""
+func (c *Checker) originRoot(origin *ssa.Call) string {
+	callee := origin.Call.StaticCallee()
+	if origin.Call.Signature().Recv() == nil {
+		// log.Info(): the package's global logger
+		return qualifiedName(callee.Package().Pkg, callee.Name())
+	}
+	if len(origin.Call.Args) == 0 {
+		return ""
+	}
+	c.rootMode, c.roots = true, nil
+	defer func() { c.rootMode, c.roots = false, nil }()
+	if !c.traceValue(origin.Call.Args[0], tracerLogger, make(map[ssa.Value]bool)) || len(c.roots) == 0 {
+		return ""
+	}
+	return c.roots[0]
+}
+
+// newLoggerRoot names a zerolog function at the root of a chain in root
+// mode: zerolog.New and zerolog.Nop, or a zerolog/log function returning
+// the global logger (log.With(), log.Hook(h), ...). It returns "" for
+// anything else, including log.Ctx.
+func (c *Checker) newLoggerRoot(callee *ssa.Function) string {
+	if callee.Signature.Recv() != nil || !c.pkgs.IsZerologFunc(callee) || c.pkgs.IsCtxFunc(callee) {
+		return ""
+	}
+	pkg := callee.Package().Pkg
+	switch c.pkgs.PackageKind(pkg.Path()) {
+	case typeutil.PkgCore:
+		if callee.Name() == "New" || callee.Name() == "Nop" {
+			return qualifiedName(pkg, callee.Name())
+		}
+	case typeutil.PkgLog:
+		return qualifiedName(pkg, callee.Name())
+	}
+	return ""
+}
+
+// qualifiedName returns pkg.name.
+func qualifiedName(pkg *types.Package, name string) string {
+	return pkg.Name() + "." + name
+}
+
+// ctxLoggerFixes builds the suggested fix replacing the logger of a level
+// call with log.Ctx(ctx):
+//
+//	log.Info()                              →  log.Ctx(ctx).Info()
+//	log.Logger.With().Str("k", "v").Logger().Info()
+//	                                        →  log.Ctx(ctx).With().Str("k", "v").Logger().Info()
+//	zerolog.New(w).Info()                   →  log.Ctx(ctx).Info()
+//
+// No fix is offered if the root is a local variable, which would be left
+// unused, if the root is a call with arguments (zerolog.New(w)) or holds the
+// last use of an import in the file, which would be dropped or left unused,
+// or if another package is visible as log where the fix goes.
+func (c *Checker) ctxLoggerFixes(origin *ssa.Call) []analysis.SuggestedFix {
+	file, call := c.callExprAt(origin.Pos())
+	if call == nil {
+		return nil
+	}
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	ctxCall := typeutil.CtxMethod + "(" + c.ctxName + ")"
+	if origin.Call.Signature().Recv() == nil {
+		edits, _ := c.zerologCtxEdits(file, origin, call)
+		return []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf(fixMsgCtxLogger, c.ctxName),
+			TextEdits: edits,
+		}}
+	}
+
+	root := c.rootLoggerExpr(sel.X)
+	if edit, ok := c.logFuncCtxEdit(root); ok {
+		return []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf(fixMsgCtxLogger, c.ctxName),
+			TextEdits: []analysis.TextEdit{edit},
+		}}
+	}
+	if id, ok := astutil.Unparen(root).(*ast.Ident); ok {
+		if obj := c.pass.TypesInfo.Uses[id]; obj == nil || obj.Parent() != c.pass.Pkg.Scope() {
+			return nil
+		}
+	}
+	core := c.pkgs.ZerologPackageOf(origin.Call.Signature().Recv().Type())
+	if core == nil {
+		return nil
+	}
+	path := core.Path() + "/log"
+	name, importEdits := c.importName(file, path, "log")
+	if !c.nameRefersTo(root.Pos(), name, path) || c.lossyRoot(file, root, path) {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf(fixMsgCtxLogger, c.ctxName),
+		TextEdits: append(importEdits, analysis.TextEdit{
+			Pos:     root.Pos(),
+			End:     root.End(),
+			NewText: []byte(name + "." + ctxCall),
+		}),
+	}}
+}
+
+// logFuncCtxEdit inserts Ctx(ctx) into a call of a zerolog/log function
+// at the root of a chain: log.With() → log.Ctx(ctx).With().
+func (c *Checker) logFuncCtxEdit(root ast.Expr) (analysis.TextEdit, bool) {
+	call, ok := astutil.Unparen(root).(*ast.CallExpr)
+	if !ok {
+		return analysis.TextEdit{}, false
+	}
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return analysis.TextEdit{}, false
+	}
+	fn, ok := c.pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Signature().Recv() != nil || c.pkgs.PackageKind(fn.Pkg().Path()) != typeutil.PkgLog {
+		return analysis.TextEdit{}, false
+	}
+	return analysis.TextEdit{
+		Pos:     sel.Sel.Pos(),
+		End:     sel.Sel.Pos(),
+		NewText: []byte(typeutil.CtxMethod + "(" + c.ctxName + ")."),
+	}, true
+}
+
+// holdsLastImportUse returns true if expr refers to an import, other than
+// the one of keep, that is not referred to anywhere else in file.
+func (c *Checker) holdsLastImportUse(file *ast.File, expr ast.Expr, keep string) bool {
+	used := make(map[*types.PkgName]bool)
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if pkgName, ok := c.pass.TypesInfo.Uses[id].(*types.PkgName); ok && pkgName.Imported().Path() != keep {
+				used[pkgName] = true
+			}
+		}
+		return true
+	})
+	ast.Inspect(file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && (id.Pos() < expr.Pos() || id.End() > expr.End()) {
+			if pkgName, ok := c.pass.TypesInfo.Uses[id].(*types.PkgName); ok {
+				delete(used, pkgName)
+			}
+		}
+		return len(used) > 0
+	})
+	return len(used) > 0
+}
+
+// nameRefersTo returns true if name at pos resolves to the import of path,
+// or to nothing (the import is about to be added).
+func (c *Checker) nameRefersTo(pos token.Pos, name, path string) bool {
+	scope := c.pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent(name, pos)
+	if obj == nil {
+		return true
+	}
+	pkgName, ok := obj.(*types.PkgName)
+	return ok && pkgName.Imported().Path() == path
+}
@@ -0,0 +1,114 @@
+package ssa
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Escaping Context Loggers
+// =============================================================================
+
+// checkCtxLoggerEscape reports loggers with a context baked in by
+// Context.Ctx that are stored somewhere outliving the function:
+//
+//	func (s *server) handle(ctx context.Context) {
+//	    s.log = logger.With().Ctx(ctx).Logger()        ← reported (field s.log)
+//	    appLog = logger.With().Ctx(ctx).Logger()       ← reported (package-level variable)
+//	    loggers <- logger.With().Ctx(ctx).Logger()     ← reported (channel)
+//	    cache[id] = logger.With().Ctx(ctx).Logger()    ← reported (map cache)
+//	    *out = logger.With().Ctx(ctx).Logger()         ← reported (*out)
+//	}
+//
+// Every later log line through them carries the request context, canceled
+// once the request ends. Loggers are traced in escape mode, where only
+// Context.Ctx and Logger.UpdateContext bind a context: zerolog.Ctx(ctx) and
+// other providers return the logger stored in the context, not one bound
+// to it. Stores to values and channels created in the function are not
+// reported.
+func (c *Checker) checkCtxLoggerEscape(instr ssa.Instruction) {
+	var val ssa.Value
+	var where string
+	switch v := instr.(type) {
+	case *ssa.Store:
+		val, where = v.Val, escapeTarget(v.Addr)
+	case *ssa.Send:
+		if !localChan(v.Chan) {
+			val, where = v.X, "a channel"
+		}
+	case *ssa.MapUpdate:
+		if name := outlivingName(v.Map); name != "" {
+			val, where = v.Value, "map "+name
+		}
+	}
+	if where == "" || !(c.pkgs.IsLogger(val.Type()) || c.pkgs.IsContext(val.Type())) {
+		return
+	}
+	if !c.bindsCtx(val) {
+		return
+	}
+	c.reportMsg(instr.Pos(), "zerolog logger with a context from .Ctx() escapes to "+where+
+		"; its context ends with the request")
+}
+
+// bindsCtx returns true if the Logger or Context v has a context bound by
+// Context.Ctx on every path.
+func (c *Checker) bindsCtx(v ssa.Value) bool {
+	t, _ := tracerFor(c.pkgs, v.Type())
+	c.escapeMode = true
+	defer func() { c.escapeMode = false }()
+	return c.traceValue(v, t, make(map[ssa.Value]bool))
+}
+
+// escapeTarget describes the location a Store writes to, or returns "" if it
+// does not outlive the function. Pointers from parameters, or loaded from
+// their fields, point to the caller's variables.
+func escapeTarget(addr ssa.Value) string {
+	switch a := addr.(type) {
+	case *ssa.Global:
+		return "package-level variable " + a.Name()
+	case *ssa.FieldAddr:
+		if name := outlivingName(a.X); name != "" {
+			return "field " + name + "." + typeutil.FieldName(a.X.Type(), a.Field)
+		}
+	case *ssa.Parameter, *ssa.UnOp:
+		if name := outlivingName(a); name != "" {
+			return "*" + name
+		}
+	}
+	return ""
+}
+
+// outlivingName names a value that outlives the function: a parameter (or
+// receiver), a field of one, or a package-level variable. It returns "" for
+// anything else, such as values created in the function.
+func outlivingName(v ssa.Value) string {
+	switch val := v.(type) {
+	case *ssa.Parameter:
+		if _, ok := val.Type().Underlying().(*types.Pointer); ok {
+			return val.Name()
+		}
+		if _, ok := val.Type().Underlying().(*types.Map); ok {
+			return val.Name()
+		}
+	case *ssa.Global:
+		return val.Name()
+	case *ssa.UnOp:
+		if val.Op != token.MUL {
+			return ""
+		}
+		switch x := val.X.(type) {
+		case *ssa.Global:
+			return x.Name()
+		case *ssa.FieldAddr:
+			if name := outlivingName(x.X); name != "" {
+				return name + "." + typeutil.FieldName(x.X.Type(), x.Field)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,384 @@
+package ssa
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/config"
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Suggested Fixes
+// =============================================================================
+
+// Fix messages. They are shared by all diagnostics of the same kind.
+const (
+	fixMsgInsertCtx  = "Add .Ctx(%s) after the level call"
+	fixMsgZerologCtx = "Use zerolog.Ctx(%s) as the logger"
+	fixMsgPrint      = "Rewrite as %s().Ctx(%s) event chain"
+	fixMsgWithCtx    = "Add .With().Ctx(%s).Logger() before WithContext"
+)
+
+// ctxFixes builds the suggested fixes for an event chain missing .Ctx(ctx).
+//
+// Two styles are offered; the one preferred by config.FixStyle comes first
+// and is applied by -fix:
+//
+//	logger.With().Str("k", "v").Logger().Info().Msg("x")
+//	                                          │
+//	  ctx:          ...Logger().Info().Ctx(ctx).Msg("x")
+//	  zerolog-ctx:  zerolog.Ctx(ctx).With().Str("k", "v").Logger().Info().Msg("x")
+//
+// The level calls are found by following the Event back through SSA, so
+// chains split across variables and branches are fixed as well. No fix is
+// offered when any origin cannot be located.
+func (c *Checker) ctxFixes(ev ssa.Value) []analysis.SuggestedFix {
+	origins, ok := c.eventOrigins(ev, make(map[ssa.Value]bool))
+	if !ok || len(origins) == 0 {
+		return nil
+	}
+
+	// In strict mode, zerolog.Ctx(ctx) does not attach the context
+	var insertEdits, loggerEdits []analysis.TextEdit
+	loggerOK := !c.strict()
+	for _, origin := range origins {
+		file, call := c.callExprAt(origin.Pos())
+		if call == nil {
+			return nil
+		}
+		insertEdits = append(insertEdits, analysis.TextEdit{
+			Pos:     call.Rparen + 1,
+			End:     call.Rparen + 1,
+			NewText: []byte(".Ctx(" + c.ctxName + ")"),
+		})
+		if loggerOK {
+			edits, ok := c.zerologCtxEdits(file, origin, call)
+			loggerEdits = append(loggerEdits, edits...)
+			loggerOK = ok
+		}
+	}
+
+	fixes := []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf(fixMsgInsertCtx, c.ctxName),
+		TextEdits: insertEdits,
+	}}
+	if loggerOK {
+		loggerFix := analysis.SuggestedFix{
+			Message:   fmt.Sprintf(fixMsgZerologCtx, c.ctxName),
+			TextEdits: dedupEdits(loggerEdits),
+		}
+		if c.cfg != nil && c.cfg.FixStyle == config.FixStyleZerologCtx {
+			fixes = []analysis.SuggestedFix{loggerFix, fixes[0]}
+		} else {
+			fixes = append(fixes, loggerFix)
+		}
+	}
+	return fixes
+}
+
+// printFixes builds the suggested fix for a direct logging call, rewriting it
+// into an Event chain at the configured level (Debug by default), the way
+// zerolog implements these methods itself:
+//
+//	logger.Print(a, b)      →  logger.Debug().Ctx(ctx).Msg(fmt.Sprint(a, b))
+//	logger.Printf(f, a)     →  logger.Debug().Ctx(ctx).Msgf(f, a)
+//	logger.Println(a, b)    →  logger.Debug().Ctx(ctx).Msg(fmt.Sprintln(a, b))
+func (c *Checker) printFixes(pos token.Pos, method string) []analysis.SuggestedFix {
+	file, call := c.callExprAt(pos)
+	if call == nil {
+		return nil
+	}
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	level := config.LevelDebug.Method()
+	if c.cfg != nil && c.cfg.PrintLevel != "" {
+		level = c.cfg.PrintLevel.Method()
+	}
+	chain := level + "()." + typeutil.CtxMethod + "(" + c.ctxName + ")."
+
+	var edits []analysis.TextEdit
+	switch method {
+	case "Printf":
+		edits = []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte(chain + "Msgf")}}
+	case "Print", "Println":
+		fmtName, importEdits := c.importName(file, "fmt", "fmt")
+		sprint := fmtName + ".Sprint"
+		if method == "Println" {
+			sprint += "ln"
+		}
+		edits = append(importEdits,
+			analysis.TextEdit{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte(chain + "Msg")},
+			analysis.TextEdit{Pos: call.Lparen + 1, End: call.Lparen + 1, NewText: []byte(sprint + "(")},
+			analysis.TextEdit{Pos: call.Rparen, End: call.Rparen, NewText: []byte(")")},
+		)
+	default:
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf(fixMsgPrint, level, c.ctxName),
+		TextEdits: edits,
+	}}
+}
+
+// withContextFixes builds the suggested fix for a logger stored in a context
+// without Context.Ctx:
+//
+//	logger.WithContext(ctx)  →  logger.With().Ctx(ctx).Logger().WithContext(ctx)
+func (c *Checker) withContextFixes(call *ssa.Call) []analysis.SuggestedFix {
+	_, expr := c.callExprAt(call.Pos())
+	if expr == nil {
+		return nil
+	}
+	sel, ok := astutil.Unparen(expr.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf(fixMsgWithCtx, c.ctxName),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     sel.X.End(),
+			End:     sel.X.End(),
+			NewText: []byte(".With()." + typeutil.CtxMethod + "(" + c.ctxName + ").Logger()"),
+		}},
+	}}
+}
+
+// eventOrigins finds the level calls (Logger.Info(), log.Info(), ...) that
+// created an Event, following Event methods and Phi nodes.
+// ok is false if some origin cannot be determined.
+func (c *Checker) eventOrigins(v ssa.Value, visited map[ssa.Value]bool) (origins []*ssa.Call, ok bool) {
+	if visited[v] {
+		return nil, true
+	}
+	visited[v] = true
+
+	switch val := v.(type) {
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if isNilConst(edge) {
+				continue
+			}
+			edgeOrigins, ok := c.eventOrigins(edge, visited)
+			if !ok {
+				return nil, false
+			}
+			origins = append(origins, edgeOrigins...)
+		}
+		return origins, true
+	case *ssa.Call:
+		callee := val.Call.StaticCallee()
+		if callee == nil || !c.pkgs.ReturnsEvent(callee) {
+			return nil, false
+		}
+		recv := val.Call.Signature().Recv()
+		switch {
+		case recv != nil && c.pkgs.IsEvent(recv.Type()) && len(val.Call.Args) > 0:
+			// Event → Event (Str, Int, ...): keep walking up the chain
+			return c.eventOrigins(val.Call.Args[0], visited)
+		case recv != nil && c.pkgs.IsLogger(recv.Type()):
+			return []*ssa.Call{val}, true
+		case recv == nil && c.pkgs.IsZerologFunc(callee):
+			return []*ssa.Call{val}, true
+		}
+	}
+	return nil, false
+}
+
+// zerologCtxEdits rewrites the logger a level call is made on to zerolog.Ctx(ctx).
+//
+// For methods, the root of the Logger/Context chain is replaced, so derived
+// loggers keep their fields. For package-level functions of zerolog/log,
+// log.Info() becomes log.Ctx(ctx).Info(). Roots whose replacement would lose
+// code (see lossyRoot) get no edits.
+func (c *Checker) zerologCtxEdits(file *ast.File, origin *ssa.Call, call *ast.CallExpr) ([]analysis.TextEdit, bool) {
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+
+	// log.Info() → log.Ctx(ctx).Info()
+	if origin.Call.Signature().Recv() == nil {
+		return []analysis.TextEdit{{
+			Pos:     sel.Sel.Pos(),
+			End:     sel.Sel.Pos(),
+			NewText: []byte(typeutil.CtxMethod + "(" + c.ctxName + ")."),
+		}}, true
+	}
+
+	// Import the package declaring the Logger, which may be a fork
+	path, name := typeutil.ZerologPkgPath, "zerolog"
+	if pkg := c.pkgs.ZerologPackageOf(origin.Call.Signature().Recv().Type()); pkg != nil {
+		path, name = pkg.Path(), pkg.Name()
+	}
+	root := c.rootLoggerExpr(sel.X)
+	if c.lossyRoot(file, root, path) {
+		return nil, false
+	}
+	name, importEdits := c.importName(file, path, name)
+	edits := append(importEdits, analysis.TextEdit{
+		Pos:     root.Pos(),
+		End:     root.End(),
+		NewText: []byte(name + "." + typeutil.CtxMethod + "(" + c.ctxName + ")"),
+	})
+	return edits, true
+}
+
+// rootLoggerExpr walks down a Logger/Context method chain to its root:
+//
+//	logger.With().Str("k", "v").Logger()  →  logger
+func (c *Checker) rootLoggerExpr(x ast.Expr) ast.Expr {
+	for {
+		call, ok := astutil.Unparen(x).(*ast.CallExpr)
+		if !ok {
+			return x
+		}
+		sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return x
+		}
+		tv, ok := c.pass.TypesInfo.Types[sel.X]
+		if !ok || !(c.pkgs.IsLogger(tv.Type) || c.pkgs.IsContext(tv.Type)) {
+			return x
+		}
+		x = sel.X
+	}
+}
+
+// lossyRoot returns true if replacing the root of a logger chain would lose
+// code: a local variable, left unused along with the writer and settings it
+// was built with, a call with arguments (zerolog.New(w)), or the last use in
+// file of an import other than the one of keep.
+func (c *Checker) lossyRoot(file *ast.File, root ast.Expr, keep string) bool {
+	switch r := astutil.Unparen(root).(type) {
+	case *ast.CallExpr:
+		if len(r.Args) > 0 {
+			return true
+		}
+	case *ast.Ident:
+		if c.isLocalVar(file, r) {
+			return true
+		}
+	}
+	return c.holdsLastImportUse(file, root, keep)
+}
+
+// isLocalVar returns true if id refers to a variable declared in the body of
+// a function enclosing it. Parameters are not local: dropping a use of them
+// keeps the code valid.
+func (c *Checker) isLocalVar(file *ast.File, id *ast.Ident) bool {
+	obj, ok := c.pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok {
+		return false
+	}
+	path, _ := astutil.PathEnclosingInterval(file, id.Pos(), id.End())
+	for _, n := range path {
+		var body *ast.BlockStmt
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+		if body != nil && body.Pos() <= obj.Pos() && obj.Pos() < body.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// callExprAt returns the call expression whose opening parenthesis is at pos,
+// along with its file. SSA call instructions are positioned there.
+func (c *Checker) callExprAt(pos token.Pos) (*ast.File, *ast.CallExpr) {
+	if !pos.IsValid() {
+		return nil, nil
+	}
+	for _, file := range c.pass.Files {
+		if pos < file.FileStart || pos >= file.FileEnd {
+			continue
+		}
+		path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+		for _, n := range path {
+			if call, ok := n.(*ast.CallExpr); ok && call.Lparen == pos {
+				return file, call
+			}
+		}
+		return file, nil
+	}
+	return nil, nil
+}
+
+// importName returns the name under which file imports path. If path is not
+// imported, an edit adding the import under name is returned as well.
+func (c *Checker) importName(file *ast.File, path, name string) (string, []analysis.TextEdit) {
+	quoted := strconv.Quote(path)
+	for _, spec := range file.Imports {
+		if spec.Path.Value != quoted {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name, nil
+		}
+		return name, nil
+	}
+
+	// Append to the first import declaration, or add one after the package clause.
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			// Break the line of a one-line group: import ("context")
+			text := "\t" + quoted + "\n"
+			last := gen.Lparen
+			if len(gen.Specs) > 0 {
+				last = gen.Specs[len(gen.Specs)-1].End()
+			}
+			if c.pass.Fset.Position(last).Line == c.pass.Fset.Position(gen.Rparen).Line {
+				text = "\n" + text
+			}
+			return name, []analysis.TextEdit{{
+				Pos:     gen.Rparen,
+				End:     gen.Rparen,
+				NewText: []byte(text),
+			}}
+		}
+		return name, []analysis.TextEdit{{
+			Pos:     gen.End(),
+			End:     gen.End(),
+			NewText: []byte("\nimport " + quoted),
+		}}
+	}
+	return name, []analysis.TextEdit{{
+		Pos:     file.Name.End(),
+		End:     file.Name.End(),
+		NewText: []byte("\n\nimport " + quoted),
+	}}
+}
+
+// dedupEdits removes identical edits, e.g. the same import added for
+// several origins of one event.
+func dedupEdits(edits []analysis.TextEdit) []analysis.TextEdit {
+	var out []analysis.TextEdit
+	for _, e := range edits {
+		if !slices.ContainsFunc(out, func(o analysis.TextEdit) bool {
+			return o.Pos == e.Pos && o.End == e.End && string(o.NewText) == string(e.NewText)
+		}) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
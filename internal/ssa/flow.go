@@ -0,0 +1,460 @@
+package ssa
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// =============================================================================
+// Alias Flow
+// =============================================================================
+
+// fieldKey identifies a struct field across all values of the struct type.
+type fieldKey struct {
+	typ   types.Type
+	index int
+}
+
+// fieldKeyOf returns the key of a field of the struct x, or of the struct
+// x points to.
+func fieldKeyOf(x types.Type, index int) fieldKey {
+	if ptr, ok := x.Underlying().(*types.Pointer); ok {
+		x = ptr.Elem()
+	}
+	return fieldKey{x, index}
+}
+
+// aliasFlow collects the values written into the channels, slices, arrays or
+// maps that may alias a given one, following them within the package:
+//
+//	backwards  Phi edges, conversions, slicing, append, captured variables
+//	           (FreeVar to the MakeClosure binding), loads of locals,
+//	           globals and fields, element reads of outer containers
+//	forwards   stores, slicing, append and copy, MakeClosure bindings,
+//	           arguments of package functions (to their parameters), Phi
+//	           nodes and conversions, element writes of outer containers
+//
+// Writes are channel sends, stores to elements (IndexAddr, including
+// composite literals and the variadic arguments of append) and MapUpdate.
+// Globals and struct fields (by struct type and field index) are shared by
+// every function of the package. Containers that are elements of another
+// slice, array or map are followed through the outer one (see addElements).
+// Uses the flow cannot follow (returns, interfaces, element addresses taken,
+// calls of other packages, ...) make the writes unknown.
+type aliasFlow struct {
+	summaries  *Summaries
+	values     map[ssa.Value]bool
+	fields     map[fieldKey]bool
+	containers map[ssa.Value]bool // Outer containers followed, shared by nested flows
+	queue      []ssa.Value
+	written    []ssa.Value
+	ok         bool
+}
+
+func newAliasFlow(summaries *Summaries) *aliasFlow {
+	return &aliasFlow{
+		summaries:  summaries,
+		values:     make(map[ssa.Value]bool),
+		fields:     make(map[fieldKey]bool),
+		containers: make(map[ssa.Value]bool),
+		ok:         true,
+	}
+}
+
+// writes returns the values written into channels or containers aliasing
+// v, and false if some writes may be missing.
+func (f *aliasFlow) writes(v ssa.Value) ([]ssa.Value, bool) {
+	if _, ok := v.(*ssa.Parameter); ok {
+		return nil, false
+	}
+	f.add(v)
+	for len(f.queue) > 0 && f.ok {
+		v := f.queue[0]
+		f.queue = f.queue[1:]
+		f.backward(v)
+		f.forward(v)
+	}
+	return f.written, f.ok
+}
+
+func (f *aliasFlow) add(v ssa.Value) {
+	if v == nil || f.values[v] {
+		return
+	}
+	f.values[v] = true
+	f.queue = append(f.queue, v)
+}
+
+// backward adds the values v may come from.
+func (f *aliasFlow) backward(v ssa.Value) {
+	switch val := v.(type) {
+	case *ssa.MakeChan, *ssa.MakeSlice, *ssa.MakeMap, *ssa.Alloc, *ssa.Parameter, *ssa.Const:
+		// Origins (parameters are only reached forwards from a call)
+	case *ssa.UnOp:
+		if val.Op != token.MUL {
+			f.ok = false
+			return
+		}
+		f.addAddr(val.X)
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			f.add(edge)
+		}
+	case *ssa.ChangeType:
+		f.add(val.X)
+	case *ssa.Slice:
+		f.add(val.X)
+	case *ssa.Call:
+		// append(s, elems...): s and the elements
+		if !isBuiltin(val.Common(), "append") {
+			f.ok = false
+			return
+		}
+		for _, arg := range val.Call.Args {
+			f.add(arg)
+		}
+	case *ssa.FreeVar:
+		mc := makeClosureOf(val.Parent())
+		idx := freeVarIndex(val.Parent(), val)
+		if mc == nil || idx < 0 || idx >= len(mc.Bindings) {
+			f.ok = false
+			return
+		}
+		f.add(mc.Bindings[idx])
+	case *ssa.Global:
+		f.globalAccesses(val)
+	case *ssa.Field:
+		f.addField(fieldKeyOf(val.X.Type(), val.Field))
+	case *ssa.Lookup:
+		if val.CommaOk {
+			f.ok = false
+			return
+		}
+		f.addElements(val.X)
+	case *ssa.Index:
+		f.addElements(val.X)
+	case *ssa.Extract:
+		// v, ok := m[k] and range values of maps
+		switch tuple := val.Tuple.(type) {
+		case *ssa.Lookup:
+			if val.Index != 0 {
+				f.ok = false
+				return
+			}
+			f.addElements(tuple.X)
+		case *ssa.Next:
+			rng, ok := tuple.Iter.(*ssa.Range)
+			if !ok || tuple.IsString || val.Index != 2 {
+				f.ok = false
+				return
+			}
+			f.addElements(rng.X)
+		default:
+			f.ok = false
+		}
+	default:
+		f.ok = false
+	}
+}
+
+// addAddr adds the address a value is loaded from or stored to.
+func (f *aliasFlow) addAddr(addr ssa.Value) {
+	switch a := addr.(type) {
+	case *ssa.Alloc, *ssa.FreeVar, *ssa.Global:
+		f.add(a)
+	case *ssa.FieldAddr:
+		f.addField(fieldKeyOf(a.X.Type(), a.Field))
+	case *ssa.IndexAddr:
+		f.addElements(a.X)
+	default:
+		f.ok = false
+	}
+}
+
+// addElements adds the elements of the outer container c, for containers
+// nested in it: the values written into c and the reads of its elements.
+//
+//	m["a"] = append(m["a"], logger.Info().Ctx(ctx))
+//	m["a"][0].Msg("x")      // m["a"]: the append result and both reads
+func (f *aliasFlow) addElements(c ssa.Value) {
+	if f.containers[c] {
+		return
+	}
+	f.containers[c] = true
+	outer := newAliasFlow(f.summaries)
+	outer.containers = f.containers
+	written, ok := outer.writes(c)
+	if !ok {
+		f.ok = false
+		return
+	}
+	for _, v := range written {
+		f.add(v)
+	}
+	for v := range outer.values {
+		for _, read := range elementReads(v) {
+			f.add(read)
+		}
+	}
+}
+
+// elementReads returns the reads of elements of the container v: map
+// lookups (including comma-ok ones and range loops), indexing of arrays and
+// loads of element addresses.
+func elementReads(v ssa.Value) []ssa.Value {
+	if v.Referrers() == nil {
+		return nil
+	}
+	var reads []ssa.Value
+	for _, ref := range *v.Referrers() {
+		switch r := ref.(type) {
+		case *ssa.Lookup:
+			if r.X != v {
+				continue
+			}
+			if !r.CommaOk {
+				reads = append(reads, r)
+				continue
+			}
+			reads = append(reads, extractsOf(r, 0)...)
+		case *ssa.Index:
+			reads = append(reads, r)
+		case *ssa.IndexAddr:
+			for _, load := range *r.Referrers() {
+				if unop, ok := load.(*ssa.UnOp); ok && unop.Op == token.MUL {
+					reads = append(reads, unop)
+				}
+			}
+		case *ssa.Range:
+			for _, next := range *r.Referrers() {
+				if next, ok := next.(*ssa.Next); ok {
+					reads = append(reads, extractsOf(next, 2)...)
+				}
+			}
+		}
+	}
+	return reads
+}
+
+// extractsOf returns the Extracts of the index-th value of the tuple v.
+func extractsOf(v ssa.Value, index int) []ssa.Value {
+	var extracts []ssa.Value
+	for _, ref := range *v.Referrers() {
+		if ex, ok := ref.(*ssa.Extract); ok && ex.Index == index {
+			extracts = append(extracts, ex)
+		}
+	}
+	return extracts
+}
+
+// forward adds the values v flows to and collects the values written to it.
+func (f *aliasFlow) forward(v ssa.Value) {
+	refs := v.Referrers()
+	if refs == nil {
+		return
+	}
+	for _, ref := range *refs {
+		switch r := ref.(type) {
+		case *ssa.Send:
+			if r.Chan == v {
+				f.written = append(f.written, r.X)
+			} else {
+				f.ok = false
+			}
+		case *ssa.Select:
+			for _, state := range r.States {
+				if state.Chan == v && state.Dir == types.SendOnly {
+					f.written = append(f.written, state.Send)
+				}
+			}
+		case *ssa.MapUpdate:
+			switch v {
+			case r.Map:
+				f.written = append(f.written, r.Value)
+			case r.Value:
+				// An element of an outer map
+				f.addElements(r.Map)
+			default:
+				f.ok = false
+			}
+		case *ssa.IndexAddr:
+			f.forwardElem(r)
+		case *ssa.UnOp:
+			// Receives, and loads of an address in the flow
+			if r.Op == token.MUL {
+				f.add(r)
+			}
+		case *ssa.Store:
+			if r.Addr == v {
+				f.add(r.Val)
+			} else {
+				f.addAddr(r.Addr)
+			}
+		case *ssa.MakeClosure:
+			fn, ok := r.Fn.(*ssa.Function)
+			if !ok {
+				f.ok = false
+				return
+			}
+			for i, binding := range r.Bindings {
+				if binding == v && i < len(fn.FreeVars) {
+					f.add(fn.FreeVars[i])
+				}
+			}
+		case ssa.CallInstruction:
+			f.forwardCall(r, v)
+		case *ssa.Phi, *ssa.ChangeType, *ssa.Slice:
+			f.add(r.(ssa.Value))
+		case *ssa.Index, *ssa.Lookup, *ssa.Range,
+			*ssa.DebugRef, *ssa.BinOp, *ssa.If:
+			// Element reads, debug info and nil checks
+		default:
+			f.ok = false
+		}
+		if !f.ok {
+			return
+		}
+	}
+}
+
+// forwardElem collects the values stored to an element address; loads of
+// the element are reads.
+func (f *aliasFlow) forwardElem(ia *ssa.IndexAddr) {
+	if ia.Referrers() == nil {
+		return
+	}
+	for _, ref := range *ia.Referrers() {
+		switch r := ref.(type) {
+		case *ssa.Store:
+			if r.Addr == ia {
+				f.written = append(f.written, r.Val)
+			} else {
+				f.ok = false
+			}
+		case *ssa.UnOp:
+			if r.Op != token.MUL {
+				f.ok = false
+			}
+		case *ssa.DebugRef:
+		default:
+			// Element address taken (&s[i], method calls on it, ...)
+			f.ok = false
+		}
+	}
+}
+
+// forwardCall follows a value passed to a call: to the parameters of
+// functions of the package, to the result of append, between the arguments
+// of copy, and nowhere for the other builtins.
+func (f *aliasFlow) forwardCall(call ssa.CallInstruction, v ssa.Value) {
+	common := call.Common()
+	if b, ok := common.Value.(*ssa.Builtin); ok {
+		switch b.Name() {
+		case "append":
+			if result := call.Value(); result != nil {
+				f.add(result)
+			}
+		case "copy":
+			for _, arg := range common.Args {
+				f.add(arg)
+			}
+		case "close", "len", "cap", "delete", "clear":
+		default:
+			f.ok = false
+		}
+		return
+	}
+	callee := common.StaticCallee()
+	if callee == nil || callee.Blocks == nil || f.summaries == nil || callee.Pkg != f.summaries.pkg ||
+		len(callee.Params) != len(common.Args) {
+		f.ok = false
+		return
+	}
+	for i, arg := range common.Args {
+		if arg == v {
+			f.add(callee.Params[i])
+		}
+	}
+}
+
+// globalAccesses adds the loads of and values stored to a package-level
+// variable, in every function of the package.
+func (f *aliasFlow) globalAccesses(g *ssa.Global) {
+	if f.summaries == nil || g.Pkg != f.summaries.pkg {
+		f.ok = false
+		return
+	}
+	f.eachInstr(func(instr ssa.Instruction) {
+		switch i := instr.(type) {
+		case *ssa.UnOp:
+			if i.Op == token.MUL && i.X == g {
+				f.add(i)
+			}
+		case *ssa.Store:
+			if i.Addr == g {
+				f.add(i.Val)
+			}
+		}
+	})
+}
+
+// addField adds the loads of and values stored to a struct field, in every
+// function of the package.
+func (f *aliasFlow) addField(key fieldKey) {
+	if f.fields[key] {
+		return
+	}
+	f.fields[key] = true
+	// Fields of other packages' types may be set there
+	if named, ok := key.typ.(*types.Named); f.summaries == nil || ok && named.Obj().Pkg() != f.summaries.pkg.Pkg {
+		f.ok = false
+		return
+	}
+	f.eachInstr(func(instr ssa.Instruction) {
+		switch i := instr.(type) {
+		case *ssa.FieldAddr:
+			if fieldKeyOf(i.X.Type(), i.Field) != key || i.Referrers() == nil {
+				return
+			}
+			for _, ref := range *i.Referrers() {
+				switch r := ref.(type) {
+				case *ssa.UnOp:
+					if r.Op == token.MUL {
+						f.add(r)
+					}
+				case *ssa.Store:
+					if r.Addr == i {
+						f.add(r.Val)
+					}
+				}
+			}
+		case *ssa.Field:
+			if fieldKeyOf(i.X.Type(), i.Field) == key {
+				f.add(i)
+			}
+		}
+	})
+}
+
+// eachInstr calls fn for every instruction of the package's functions.
+func (f *aliasFlow) eachInstr(fn func(ssa.Instruction)) {
+	funcs := f.summaries.funcs
+	if init := f.summaries.pkg.Func("init"); init != nil {
+		funcs = append([]*ssa.Function{init}, funcs...)
+	}
+	for _, function := range funcs {
+		for _, block := range function.Blocks {
+			for _, instr := range block.Instrs {
+				fn(instr)
+			}
+		}
+	}
+}
+
+// isBuiltin returns true for calls of the builtin function name.
+func isBuiltin(common *ssa.CallCommon, name string) bool {
+	b, ok := common.Value.(*ssa.Builtin)
+	return ok && b.Name() == name
+}
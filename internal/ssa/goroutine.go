@@ -0,0 +1,390 @@
+package ssa
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/config"
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Events Shared Across Goroutines
+// =============================================================================
+
+// msgSharedEvent ends the messages of events shared across goroutines.
+const msgSharedEvent = "; events are pooled and not goroutine-safe"
+
+// checkSharedEvents reports Events handed from the current goroutine to
+// another one. Events are pooled and not goroutine-safe, so a half-built
+// event finished elsewhere races with the pool:
+//
+//	e := logger.Info().Ctx(ctx)
+//	go func() { e.Msg("done") }()             ← reported (captured)
+//	g.Go(func() error { e.Send(); ... })      ← reported (async launcher)
+//	events <- e                               ← reported (channel)
+//	go send(e)                                ← reported (argument)
+//
+//	go func() { logger.Info().Ctx(ctx).Msg("done") }()   ← OK
+//
+// The free variables of a launched closure are mapped to the MakeClosure
+// bindings, like traceFreeVar does. Captured variables only count if the
+// launching function assigns them an Event; one assigned and sent inside the
+// goroutine stays there. Sends on channels made in the function and used
+// nowhere else stay in the goroutine as well.
+func (c *Checker) checkSharedEvents(fn *ssa.Function) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			switch v := instr.(type) {
+			case *ssa.Go:
+				c.checkGoroutineStart(v, &v.Call, "a goroutine")
+			case *ssa.Call:
+				launcher := asyncLauncher(v, c.launchers())
+				if launcher == nil {
+					continue
+				}
+				for _, arg := range v.Call.Args {
+					if mc, ok := arg.(*ssa.MakeClosure); ok {
+						c.checkClosureEvents(v, mc, "the "+typeutil.ShortFuncName(launcher)+" callback")
+					}
+				}
+			case *ssa.Send:
+				if c.pkgs.IsEvent(v.X.Type()) && !localChan(v.Chan) {
+					c.reportMsg(v.Pos(), "zerolog event sent on a channel"+msgSharedEvent)
+				}
+			}
+		}
+	}
+}
+
+// checkGoroutineStart checks the function and arguments of a go statement.
+func (c *Checker) checkGoroutineStart(instr ssa.Instruction, common *ssa.CallCommon, where string) {
+	if mc, ok := common.Value.(*ssa.MakeClosure); ok {
+		c.checkClosureEvents(instr, mc, where)
+	}
+	for _, arg := range common.Args {
+		if c.pkgs.IsEvent(arg.Type()) && !isNilConst(arg) {
+			c.reportMsg(instr.Pos(), "zerolog event passed to "+where+msgSharedEvent)
+			return
+		}
+	}
+}
+
+// checkClosureEvents reports the Events captured by a closure started in
+// another goroutine.
+func (c *Checker) checkClosureEvents(instr ssa.Instruction, mc *ssa.MakeClosure, where string) {
+	fn, ok := mc.Fn.(*ssa.Function)
+	if !ok {
+		return
+	}
+	var names []string
+	for i, fv := range fn.FreeVars {
+		if i < len(mc.Bindings) && c.sharesEvent(mc.Bindings[i]) {
+			names = append(names, fv.Name())
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	c.reportMsg(instr.Pos(), "zerolog event "+strings.Join(names, ", ")+" shared with "+where+msgSharedEvent)
+}
+
+// sharesEvent returns true if the closure binding v carries an Event of the
+// launching goroutine: the Event itself, or a captured variable it assigns
+// an Event to.
+func (c *Checker) sharesEvent(v ssa.Value) bool {
+	if c.pkgs.IsEvent(v.Type()) {
+		return !isNilConst(v)
+	}
+	ptr, ok := v.Type().Underlying().(*types.Pointer)
+	if !ok || !c.pkgs.IsEvent(ptr.Elem()) {
+		return false
+	}
+	alloc, ok := v.(*ssa.Alloc)
+	if !ok {
+		// A variable captured from further out
+		return true
+	}
+	for _, ref := range *alloc.Referrers() {
+		if store, ok := ref.(*ssa.Store); ok && store.Addr == alloc && !isNilConst(store.Val) {
+			return true
+		}
+	}
+	return false
+}
+
+// localChan returns true if ch is made in the function and only sent to,
+// received from or closed there.
+func localChan(ch ssa.Value) bool {
+	mc, ok := ch.(*ssa.MakeChan)
+	if !ok {
+		return false
+	}
+	for _, ref := range *mc.Referrers() {
+		switch r := ref.(type) {
+		case *ssa.Send, *ssa.Select, *ssa.DebugRef:
+		case *ssa.UnOp:
+			// Receives
+		case *ssa.Call:
+			if _, ok := r.Call.Value.(*ssa.Builtin); !ok {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// asyncLauncher returns the function called if it is an async launcher
+// (see typeutil.IsAsyncLauncher), or nil.
+func asyncLauncher(call *ssa.Call, launchers []string) *types.Func {
+	callee := call.Call.StaticCallee()
+	if callee == nil {
+		return nil
+	}
+	fn, ok := callee.Object().(*types.Func)
+	if !ok || !typeutil.IsAsyncLauncher(fn, launchers) {
+		return nil
+	}
+	return fn
+}
+
+// launchers returns the configured async launchers.
+func (c *Checker) launchers() []string {
+	if c.cfg == nil {
+		return nil
+	}
+	return c.cfg.AsyncLaunchers
+}
+
+// =============================================================================
+// Goroutine Context Policy
+// =============================================================================
+
+// LaunchedAsync returns true if fn is a function literal started in a new
+// goroutine, by a go statement or an async launcher:
+//
+//	go func() { ... }()
+//	g.Go(func() error { ... })
+func LaunchedAsync(fn *ssa.Function, launchers []string) bool {
+	return launchCall(fn, launchers) != nil
+}
+
+// launchCall returns the go statement or async launcher call starting the
+// function literal fn, or nil.
+func launchCall(fn *ssa.Function, launchers []string) ssa.CallInstruction {
+	parent := fn.Parent()
+	if parent == nil {
+		return nil
+	}
+	var v ssa.Value = fn
+	if mc := makeClosureOf(fn); mc != nil {
+		v = mc
+	}
+	for _, block := range parent.Blocks {
+		for _, instr := range block.Instrs {
+			switch call := instr.(type) {
+			case *ssa.Go:
+				if call.Call.Value == v {
+					return call
+				}
+			case *ssa.Call:
+				if asyncLauncher(call, launchers) != nil && slices.Contains(call.Call.Args, v) {
+					return call
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkGoroutineCtx reports, with -goroutine-ctx=detached, .Ctx() calls in
+// goroutine closures given a context of the launching function:
+//
+//	func handler(ctx context.Context) {
+//	    go func() {
+//	        log.Info().Ctx(ctx).Msg("x")                          ← reported
+//	        log.Info().Ctx(context.WithoutCancel(ctx)).Msg("x")   ← OK
+//	        tctx, cancel := context.WithTimeout(ctx, d)           // derived in the goroutine
+//	        defer cancel()
+//	        log.Info().Ctx(tctx).Msg("x")                         ← OK
+//	    }()
+//	}
+//
+// That context is canceled once the launching function returns, typically
+// before the goroutine logs. The argument is followed through captured
+// variables (FreeVar to MakeClosure binding), context accessors (r.Context())
+// and fields up to the goroutine's boundary. A context captured from the
+// launching function passes if it is context.WithoutCancel or a root context
+// on every path.
+func (c *Checker) checkGoroutineCtx(call *ssa.Call) {
+	if c.cfg == nil || c.cfg.GoroutineCtx != config.GoroutineDetached {
+		return
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil || !c.isCtxSetter(&call.Call, callee) {
+		return
+	}
+	g := c.goroutineOf(call.Parent())
+	if g == nil {
+		return
+	}
+	if !c.inheritedCtx(call.Call.Args[len(call.Call.Args)-1], g, make(map[ssa.Value]bool)) {
+		return
+	}
+	c.reportMsg(call.Pos(), "zerolog .Ctx() in a goroutine is given a context of the launching function, "+
+		"canceled when it returns; use context.WithoutCancel or derive one in the goroutine (-goroutine-ctx=detached)")
+}
+
+// launchCtxAt returns true if, with -goroutine-ctx=detached, instr is in a
+// goroutine closure and the context named there (see nameAt) belongs to the
+// launching function, neither detached nor a root context. Diagnostics then
+// name the policy and suggest no fix, which would pass that context:
+//
+//	func handler(ctx context.Context) {
+//	    go func() {
+//	        log.Info().Msg("x")   ← missing .Ctx(ctx); use context.WithoutCancel(ctx)
+//	    }()
+//	}
+func (c *Checker) launchCtxAt(fn *ssa.Function, instr ssa.Instruction) bool {
+	if c.cfg == nil || c.cfg.GoroutineCtx != config.GoroutineDetached {
+		return false
+	}
+	g := c.goroutineOf(fn)
+	if g == nil {
+		return false
+	}
+	inside := true
+	for fn != nil {
+		s := c.scopeOf(fn)
+		if d := mostDerived(s.liveAt(instr)); d != nil {
+			return !inside && !detachedCtx(d.child, make(map[ssa.Value]bool))
+		}
+		// A context parameter of the goroutine closure is its own
+		if inside && slices.ContainsFunc(fn.Params, func(p *ssa.Parameter) bool { return s.inScope(p) }) {
+			return false
+		}
+		if fn == g {
+			inside = false
+		}
+		instr = makeClosureOf(fn)
+		if instr == nil {
+			break
+		}
+		fn = fn.Parent()
+	}
+	return !inside
+}
+
+// goroutineOf returns the innermost goroutine closure fn is (or is nested
+// in), or nil.
+func (c *Checker) goroutineOf(fn *ssa.Function) *ssa.Function {
+	for ; fn != nil; fn = fn.Parent() {
+		if LaunchedAsync(fn, c.launchers()) {
+			return fn
+		}
+	}
+	return nil
+}
+
+// inheritedCtx returns true if the context v, used in the goroutine closure g
+// or a function nested in it, comes from outside g on some path and is
+// neither detached nor a root context.
+func (c *Checker) inheritedCtx(v ssa.Value, g *ssa.Function, visited map[ssa.Value]bool) bool {
+	if visited[v] {
+		return false
+	}
+	visited[v] = true
+
+	switch val := v.(type) {
+	case *ssa.MakeInterface:
+		return c.inheritedCtx(val.X, g, visited)
+	case *ssa.ChangeInterface:
+		return c.inheritedCtx(val.X, g, visited)
+	case *ssa.Call:
+		// r.Context() of a captured request; other calls derive a new context
+		if recv := accessorRecv(val, c.accessors()); recv != nil {
+			return c.inheritedCtx(recv, g, visited)
+		}
+	case *ssa.UnOp:
+		if val.Op == token.MUL {
+			return c.inheritedCtx(val.X, g, visited)
+		}
+	case *ssa.FieldAddr:
+		return c.inheritedCtx(val.X, g, visited)
+	case *ssa.Field:
+		return c.inheritedCtx(val.X, g, visited)
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if c.inheritedCtx(edge, g, visited) {
+				return true
+			}
+		}
+	case *ssa.FreeVar:
+		fn := val.Parent()
+		mc := makeClosureOf(fn)
+		idx := freeVarIndex(fn, val)
+		if mc == nil || idx < 0 || idx >= len(mc.Bindings) {
+			return false
+		}
+		if fn != g {
+			return c.inheritedCtx(mc.Bindings[idx], g, visited)
+		}
+		// Captured from the launching function
+		return !detachedCtx(mc.Bindings[idx], make(map[ssa.Value]bool))
+	}
+	return false
+}
+
+// detachedCtx returns true if the context (or holder of a context) v is
+// context.WithoutCancel or a root context on every path, following local
+// variables and captured variables.
+func detachedCtx(v ssa.Value, visited map[ssa.Value]bool) bool {
+	if visited[v] {
+		return true
+	}
+	visited[v] = true
+
+	switch val := v.(type) {
+	case *ssa.MakeInterface:
+		return detachedCtx(val.X, visited)
+	case *ssa.ChangeInterface:
+		return detachedCtx(val.X, visited)
+	case *ssa.UnOp:
+		if val.Op == token.MUL {
+			return detachedCtx(val.X, visited)
+		}
+	case *ssa.Alloc:
+		stored := findAllStoredValues(val)
+		for _, s := range stored {
+			if !detachedCtx(s, visited) {
+				return false
+			}
+		}
+		return len(stored) > 0
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if !detachedCtx(edge, visited) {
+				return false
+			}
+		}
+		return len(val.Edges) > 0
+	case *ssa.FreeVar:
+		mc := makeClosureOf(val.Parent())
+		idx := freeVarIndex(val.Parent(), val)
+		return mc != nil && idx >= 0 && idx < len(mc.Bindings) && detachedCtx(mc.Bindings[idx], visited)
+	case *ssa.Call:
+		if callee := val.Call.StaticCallee(); callee != nil && typeutil.IsDetachFunc(callee) {
+			return true
+		}
+	}
+	if typeutil.IsContextType(v.Type()) {
+		return isRootContext(v, make(map[ssa.Value]bool))
+	}
+	return false
+}
@@ -0,0 +1,376 @@
+package ssa
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/facts"
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Context-Reading Hooks
+// =============================================================================
+
+// With -hook-aware, a context is only required where something reads it:
+// on chains whose logger has a hook attached that calls Event.GetCtx.
+//
+//	type traceHook struct{}
+//	func (traceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+//	    span := trace.SpanFromContext(e.GetCtx())        → reads-ctx
+//	}
+//
+//	var Logger = zerolog.New(os.Stdout).Hook(traceHook{})   → hooked
+//
+//	func handler(ctx context.Context) {
+//	    Logger.Info().Msg("x")                   ← reported
+//	    zerolog.New(os.Stdout).Info().Msg("x")   ← OK: nothing reads ctx
+//	}
+//
+// Hooks are followed through Logger.Hook and log.Hook, package-level
+// loggers (including log.Logger) and helper functions returning hooked
+// loggers, across packages through facts. Loggers of unknown origin
+// (parameters, fields, zerolog.Ctx, log.Logger not hooked in this package)
+// may have a hook attached elsewhere, so they need a context.
+
+// needsCtx returns false if, in hook-aware mode, the logger behind v is
+// known to have no context-reading hook attached.
+func (c *Checker) needsCtx(v ssa.Value) bool {
+	if !c.summaries.hookAware() {
+		return true
+	}
+	return c.summaries.hasCtxHook(v, true, make(map[ssa.Value]bool))
+}
+
+// hookAware returns true if contexts are only required for hooked loggers
+// (see config.Config.HookAware).
+func (s *Summaries) hookAware() bool {
+	return s != nil && s.cfg != nil && s.cfg.HookAware
+}
+
+// exportHookFacts exports the hook facts of an exported function.
+func (s *Summaries) exportHookFacts(fn *ssa.Function, obj types.Object) {
+	if fact := s.readsCtxFact(fn); fact != nil {
+		s.pass.ExportObjectFact(obj, fact)
+	}
+	if fact := s.hookedFact(fn); fact != nil {
+		s.pass.ExportObjectFact(obj, fact)
+	}
+}
+
+// exportHookedGlobals exports facts for the exported package-level
+// variables holding a hooked logger.
+func (s *Summaries) exportHookedGlobals() {
+	for _, member := range s.pkg.Members {
+		g, ok := member.(*ssa.Global)
+		if !ok || g.Object() == nil || !g.Object().Exported() || !s.globalHooked(g) {
+			continue
+		}
+		s.pass.ExportObjectFact(g.Object(), new(facts.HookedFact))
+	}
+}
+
+// readsCtxFact returns a fact if fn calls Event.GetCtx, or nil.
+func (s *Summaries) readsCtxFact(fn *ssa.Function) *facts.ReadsCtxFact {
+	return summarize(s, fn, &s.reads, s.summarizeReadsCtx)
+}
+
+// hookedFact returns a fact if fn returns a hooked logger, or nil.
+func (s *Summaries) hookedFact(fn *ssa.Function) *facts.HookedFact {
+	return summarize(s, fn, &s.hooked, s.summarizeHooked)
+}
+
+// summarizeReadsCtx looks for Event.GetCtx calls in fn, its function
+// literals and the functions it calls.
+func (s *Summaries) summarizeReadsCtx(fn *ssa.Function) *facts.ReadsCtxFact {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(ssa.CallInstruction)
+			if !ok {
+				continue
+			}
+			callee := call.Common().StaticCallee()
+			if callee == nil {
+				continue
+			}
+			if s.pkgs.IsGetCtx(callee) || s.readsCtxFact(callee) != nil {
+				return new(facts.ReadsCtxFact)
+			}
+		}
+	}
+	for _, anon := range fn.AnonFuncs {
+		if s.readsCtxFact(anon) != nil {
+			return new(facts.ReadsCtxFact)
+		}
+	}
+	return nil
+}
+
+// summarizeHooked checks whether any return value of fn is a hooked logger.
+func (s *Summaries) summarizeHooked(fn *ssa.Function) *facts.HookedFact {
+	for _, block := range fn.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		for _, res := range ret.Results {
+			if _, ok := tracerFor(s.pkgs, res.Type()); ok && s.hasCtxHook(res, false, make(map[ssa.Value]bool)) {
+				return new(facts.HookedFact)
+			}
+		}
+	}
+	return nil
+}
+
+// hasCtxHook returns true if a context-reading hook is attached to the
+// Logger, Context or Event v, on any path. Loggers of unknown origin yield
+// unknown: true when checking, false when summarizing into facts, which only
+// record known hooks.
+func (s *Summaries) hasCtxHook(v ssa.Value, unknown bool, visited map[ssa.Value]bool) bool {
+	if visited[v] {
+		return false
+	}
+	visited[v] = true
+
+	switch val := v.(type) {
+	case *ssa.Call:
+		return s.callHasCtxHook(val.Common(), unknown, visited)
+	case *ssa.Extract:
+		if call, ok := val.Tuple.(*ssa.Call); ok {
+			return s.callHasCtxHook(call.Common(), unknown, visited)
+		}
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if s.hasCtxHook(edge, unknown, visited) {
+				return true
+			}
+		}
+		return false
+	case *ssa.UnOp:
+		if val.Op == token.MUL {
+			return s.hasCtxHook(val.X, unknown, visited)
+		}
+	case *ssa.Global:
+		return s.globalHasCtxHook(val, unknown)
+	case *ssa.Alloc:
+		for _, stored := range findAllStoredValues(val) {
+			if s.hasCtxHook(stored, unknown, visited) {
+				return true
+			}
+		}
+		return false
+	case *ssa.FreeVar:
+		mc := makeClosureOf(val.Parent())
+		if idx := freeVarIndex(val.Parent(), val); mc != nil && idx >= 0 && idx < len(mc.Bindings) {
+			return s.hasCtxHook(mc.Bindings[idx], unknown, visited)
+		}
+	}
+	// Parameters, fields, map and channel values
+	return unknown
+}
+
+// callHasCtxHook handles calls in hasCtxHook:
+//
+//	logger.Hook(h)          h reads the context, or logger is hooked
+//	logger.With()...        the receiver is hooked
+//	log.Info(), log.Hook(h) log.Logger is hooked (or h reads the context)
+//	newLogger()             the function returns a hooked logger
+func (s *Summaries) callHasCtxHook(common *ssa.CallCommon, unknown bool, visited map[ssa.Value]bool) bool {
+	callee := common.StaticCallee()
+	if callee == nil {
+		return unknown
+	}
+	if !s.pkgs.IsZerologFunc(callee) {
+		return s.hookedFact(callee) != nil
+	}
+	// The logger of a context is unknown
+	if s.pkgs.IsCtxFunc(callee) {
+		return unknown
+	}
+
+	for _, arg := range common.Args {
+		if s.isCtxHook(arg, make(map[ssa.Value]bool)) {
+			return true
+		}
+	}
+	if recv := common.Signature().Recv(); recv != nil {
+		return s.pkgs.IsZerologValue(recv.Type()) && len(common.Args) > 0 &&
+			s.hasCtxHook(common.Args[0], unknown, visited)
+	}
+	if s.pkgs.PackageKind(callee.Package().Pkg.Path()) == typeutil.PkgLog {
+		if g := callee.Package().Var("Logger"); g != nil {
+			return s.globalHasCtxHook(g, unknown)
+		}
+	}
+	return false
+}
+
+// isCtxHook returns true if the zerolog.Hook (or slice of hooks) v reads the
+// context on some path. The Run method of its concrete type is summarized;
+// zerolog.HookFunc conversions are followed to the converted function.
+func (s *Summaries) isCtxHook(v ssa.Value, visited map[ssa.Value]bool) bool {
+	if visited[v] {
+		return false
+	}
+	visited[v] = true
+
+	if t := v.Type(); !types.IsInterface(t) && !s.pkgs.IsHookFunc(t) {
+		if run := s.hookRun(t); run != nil {
+			return s.runReadsCtx(run)
+		}
+	}
+
+	switch val := v.(type) {
+	case *ssa.MakeInterface:
+		return s.isCtxHook(val.X, visited)
+	case *ssa.ChangeType:
+		if s.pkgs.IsHookFunc(val.Type()) {
+			return s.funcReadsCtx(val.X)
+		}
+		return s.isCtxHook(val.X, visited)
+	case *ssa.Convert:
+		if s.pkgs.IsHookFunc(val.Type()) {
+			return s.funcReadsCtx(val.X)
+		}
+	case *ssa.Function, *ssa.MakeClosure:
+		return s.funcReadsCtx(val)
+	case *ssa.Slice:
+		// Variadic arguments: hooks are stored to the elements of an array
+		return s.isCtxHook(val.X, visited)
+	case *ssa.UnOp:
+		if val.Op == token.MUL {
+			return s.isCtxHook(val.X, visited)
+		}
+	case *ssa.Alloc:
+		for _, stored := range append(storedElems(val), findAllStoredValues(val)...) {
+			if s.isCtxHook(stored, visited) {
+				return true
+			}
+		}
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if s.isCtxHook(edge, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hookRun returns the Run method of a concrete zerolog.Hook implementation.
+func (s *Summaries) hookRun(t types.Type) *types.Func {
+	sel := types.NewMethodSet(t).Lookup(nil, "Run")
+	if sel == nil {
+		return nil
+	}
+	run, ok := sel.Obj().(*types.Func)
+	if !ok {
+		return nil
+	}
+	sig := run.Signature()
+	if sig.Params().Len() != 3 || !s.pkgs.IsEvent(sig.Params().At(0).Type()) {
+		return nil
+	}
+	return run
+}
+
+// runReadsCtx returns true if the Run method of a hook calls Event.GetCtx.
+func (s *Summaries) runReadsCtx(run *types.Func) bool {
+	if fn := s.pkg.Prog.FuncValue(run); fn != nil {
+		return s.readsCtxFact(fn) != nil
+	}
+	return run.Pkg() != s.pass.Pkg && s.pass.ImportObjectFact(run, new(facts.ReadsCtxFact))
+}
+
+// funcReadsCtx returns true if the function value v calls Event.GetCtx.
+func (s *Summaries) funcReadsCtx(v ssa.Value) bool {
+	switch fn := v.(type) {
+	case *ssa.Function:
+		return s.readsCtxFact(fn) != nil
+	case *ssa.MakeClosure:
+		if f, ok := fn.Fn.(*ssa.Function); ok {
+			return s.readsCtxFact(f) != nil
+		}
+	}
+	return false
+}
+
+// storedElems returns the values stored to the elements of an array.
+func storedElems(alloc *ssa.Alloc) []ssa.Value {
+	var stored []ssa.Value
+	for _, ref := range *alloc.Referrers() {
+		ia, ok := ref.(*ssa.IndexAddr)
+		if !ok || ia.Referrers() == nil {
+			continue
+		}
+		for _, iar := range *ia.Referrers() {
+			if store, ok := iar.(*ssa.Store); ok && store.Addr == ia {
+				stored = append(stored, store.Val)
+			}
+		}
+	}
+	return stored
+}
+
+// globalHooked returns true if a hooked logger is assigned to g, in this
+// package or (through facts) in the one declaring it.
+func (s *Summaries) globalHooked(g *ssa.Global) bool {
+	if s.globals == nil {
+		s.globals = s.hookedGlobals()
+	}
+	if s.globals[g] {
+		return true
+	}
+	obj := g.Object()
+	return obj != nil && obj.Pkg() != s.pass.Pkg && s.pass.ImportObjectFact(obj, new(facts.HookedFact))
+}
+
+// globalHasCtxHook returns true if g is hooked. The global logger of a
+// zerolog/log package may be hooked by any package, such as main, so it is
+// unknown unless hooked here.
+func (s *Summaries) globalHasCtxHook(g *ssa.Global, unknown bool) bool {
+	if s.globalHooked(g) {
+		return true
+	}
+	return unknown && g.Pkg != nil && s.pkgs.PackageKind(g.Pkg.Pkg.Path()) == typeutil.PkgLog
+}
+
+// hookedGlobals finds the package-level variables, of any package, that
+// functions of the current package assign a hooked logger to:
+//
+//	var Logger = zerolog.New(os.Stdout).Hook(traceHook{})   // in init
+//	func setup() { log.Logger = log.Hook(traceHook{}) }
+//
+// Assignments may copy other hooked globals, so the scan is repeated until
+// nothing changes.
+func (s *Summaries) hookedGlobals() map[*ssa.Global]bool {
+	s.globals = make(map[*ssa.Global]bool)
+	fns := s.funcs
+	if init := s.pkg.Func("init"); init != nil {
+		fns = append([]*ssa.Function{init}, fns...)
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range fns {
+			for _, block := range fn.Blocks {
+				for _, instr := range block.Instrs {
+					store, ok := instr.(*ssa.Store)
+					if !ok {
+						continue
+					}
+					g, ok := store.Addr.(*ssa.Global)
+					if !ok || s.globals[g] || !s.pkgs.IsLogger(store.Val.Type()) {
+						continue
+					}
+					if s.hasCtxHook(store.Val, false, make(map[ssa.Value]bool)) {
+						s.globals[g] = true
+						changed = true
+					}
+				}
+			}
+		}
+	}
+	return s.globals
+}
@@ -0,0 +1,258 @@
+package ssa
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Context Provenance
+// =============================================================================
+
+// checkCtxArgument checks that a context passed to zerolog derives from a
+// context of the function:
+//
+//	func handler(ctx context.Context) {
+//	    log.Info().Ctx(context.Background()).Msg("x")   ← reported (root)
+//	    log.Ctx(context.TODO()).Info().Msg("x")         ← reported (root)
+//	    log.Info().Ctx(nil).Msg("x")                    ← reported (root)
+//	    log.Info().Ctx(appCtx).Msg("x")                 ← reported (global)
+//	    log.Info().Ctx(tctx).Msg("x")                   // tctx derived from ctx: OK
+//	}
+//
+// Covered calls are Event.Ctx, Context.Ctx, zerolog.Ctx and log.Ctx, including
+// deferred ones. The argument is traced back on every path (see
+// ctxFromFunction); root contexts get their own message.
+func (c *Checker) checkCtxArgument(call ssa.CallInstruction) {
+	if c.rootCtxAllowed {
+		return
+	}
+	common := call.Common()
+	callee := common.StaticCallee()
+	if callee == nil || !c.isCtxSetter(common, callee) {
+		return
+	}
+	arg := common.Args[len(common.Args)-1]
+	if c.ctxFromFunction(arg, make(map[ssa.Value]bool)) {
+		return
+	}
+	format := "zerolog .Ctx() is given a context not derived from %s"
+	if isRootContext(arg, make(map[ssa.Value]bool)) {
+		format = "zerolog .Ctx() is given a root context instead of %s"
+	}
+	c.report(call.Pos(), format, c.ctxArgFixes(common)...)
+}
+
+// isCtxSetter returns true for calls attaching a context.Context to zerolog:
+// Event.Ctx(ctx), Context.Ctx(ctx), zerolog.Ctx(ctx) and log.Ctx(ctx).
+func (c *Checker) isCtxSetter(common *ssa.CallCommon, callee *ssa.Function) bool {
+	if len(common.Args) == 0 {
+		return false
+	}
+	if c.pkgs.IsCtxFunc(callee) {
+		return callee.Name() == typeutil.CtxMethod
+	}
+	recv := common.Signature().Recv()
+	return callee.Name() == typeutil.CtxMethod && recv != nil &&
+		(c.pkgs.IsEvent(recv.Type()) || c.pkgs.IsContext(recv.Type()))
+}
+
+// ctxFromFunction returns true if the context v derives, on every path, from
+// a context of the function:
+//
+//	ctx                        context parameters (closures: of the function
+//	                           they are nested in, through captured variables)
+//	r.Context()                context accessors
+//	h.ctx                      context fields of parameters (-ctx-fields)
+//	context.WithTimeout(ctx)   calls deriving a context from one of these
+//	ctx := context.TODO()      contexts created in the body of functions
+//	                           without one (see scopeOf)
+//
+// Fields of structs built in the function are followed to the values stored
+// to them. Anything else (root contexts, nil, globals, other fields, results
+// of calls without a context argument) does not derive from the function.
+func (c *Checker) ctxFromFunction(v ssa.Value, visited map[ssa.Value]bool) bool {
+	if visited[v] {
+		return true
+	}
+	visited[v] = true
+	if c.isDerivation(v) {
+		return true
+	}
+
+	switch val := v.(type) {
+	case *ssa.Parameter:
+		return true
+	case *ssa.MakeInterface:
+		return c.ctxFromFunction(val.X, visited)
+	case *ssa.ChangeInterface:
+		return c.ctxFromFunction(val.X, visited)
+	case *ssa.ChangeType:
+		return c.ctxFromFunction(val.X, visited)
+	case *ssa.TypeAssert:
+		return c.ctxFromFunction(val.X, visited)
+	case *ssa.Extract:
+		return c.ctxFromFunction(val.Tuple, visited)
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if !c.ctxFromFunction(edge, visited) {
+				return false
+			}
+		}
+		return len(val.Edges) > 0
+	case *ssa.FreeVar:
+		mc := makeClosureOf(val.Parent())
+		idx := freeVarIndex(val.Parent(), val)
+		return mc != nil && idx >= 0 && idx < len(mc.Bindings) && c.ctxFromFunction(mc.Bindings[idx], visited)
+	case *ssa.Alloc:
+		return c.allFromFunction(findAllStoredValues(val), visited)
+	case *ssa.UnOp:
+		if val.Op != token.MUL {
+			return false
+		}
+		if fa, ok := val.X.(*ssa.FieldAddr); ok {
+			return c.fieldCtxFromFunction(fa.X, fa.Field, visited)
+		}
+		return c.ctxFromFunction(val.X, visited)
+	case *ssa.Field:
+		return c.fieldCtxFromFunction(val.X, val.Field, visited)
+	case *ssa.Call:
+		if callee := val.Call.StaticCallee(); callee != nil && typeutil.IsRootContextFunc(callee) {
+			return false
+		}
+		if accessorRecv(val, c.accessors()) != nil {
+			return true
+		}
+		for _, arg := range val.Call.Args {
+			if typeutil.IsContextType(arg.Type()) {
+				return c.ctxFromFunction(arg, visited)
+			}
+		}
+	}
+	return false
+}
+
+// isDerivation returns true if v is the child of a derivation in the scope of
+// the function computing it, including local sources.
+func (c *Checker) isDerivation(v ssa.Value) bool {
+	instr, ok := v.(ssa.Instruction)
+	if !ok || instr.Parent() == nil {
+		return false
+	}
+	for _, d := range c.scopeOf(instr.Parent()).derivations {
+		if d.child == v {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldCtxFromFunction returns true if a context field of the struct x (or
+// the struct x points to) derives from a context of the function: a field of
+// a parameter with -ctx-fields, or a field of a struct built in the function
+// storing such a context.
+func (c *Checker) fieldCtxFromFunction(x ssa.Value, field int, visited map[ssa.Value]bool) bool {
+	fields := c.cfg != nil && c.cfg.CtxFields
+	switch base := ctxVar(x).(type) {
+	case *ssa.Parameter, *ssa.FreeVar:
+		return fields
+	case *ssa.Alloc:
+		// Parameters captured by closures are spilled to a local
+		if stored := findAllStoredValues(base); len(stored) == 1 {
+			if _, ok := stored[0].(*ssa.Parameter); ok {
+				return fields
+			}
+		}
+		return c.allFromFunction(storesToField(base, field), visited)
+	}
+	return false
+}
+
+// allFromFunction returns true if there are values and all of them derive
+// from a context of the function.
+func (c *Checker) allFromFunction(values []ssa.Value, visited map[ssa.Value]bool) bool {
+	for _, v := range values {
+		if !c.ctxFromFunction(v, visited) {
+			return false
+		}
+	}
+	return len(values) > 0
+}
+
+// isRootContext returns true if v is context.Background(), context.TODO() or
+// nil on every path, possibly wrapped by context.With* functions.
+func isRootContext(v ssa.Value, visited map[ssa.Value]bool) bool {
+	if visited[v] {
+		return true
+	}
+	visited[v] = true
+
+	switch val := v.(type) {
+	case *ssa.Const:
+		return val.Value == nil
+	case *ssa.MakeInterface:
+		return isRootContext(val.X, visited)
+	case *ssa.ChangeInterface:
+		return isRootContext(val.X, visited)
+	case *ssa.Extract:
+		return isRootContext(val.Tuple, visited)
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if !isRootContext(edge, visited) {
+				return false
+			}
+		}
+		return len(val.Edges) > 0
+	case *ssa.Call:
+		callee := val.Call.StaticCallee()
+		if callee == nil {
+			return false
+		}
+		if typeutil.IsRootContextFunc(callee) {
+			return true
+		}
+		if typeutil.IsContextDeriveFunc(callee) && len(val.Call.Args) > 0 {
+			return isRootContext(val.Call.Args[0], visited)
+		}
+	}
+	return false
+}
+
+// ctxArgFixes suggests passing the function's context instead of the argument.
+func (c *Checker) ctxArgFixes(common *ssa.CallCommon) []analysis.SuggestedFix {
+	_, expr := c.callExprAt(common.Pos())
+	if expr == nil || len(expr.Args) == 0 {
+		return nil
+	}
+	arg := expr.Args[len(expr.Args)-1]
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Pass %s", c.ctxName),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     arg.Pos(),
+			End:     arg.End(),
+			NewText: []byte(c.ctxName),
+		}},
+	}}
+}
+
+// isRootCtxAllowed returns true if fn, or the function it is nested in, is
+// listed in config.RootCtxFuncs.
+func (c *Checker) isRootCtxAllowed(fn *ssa.Function) bool {
+	if c.cfg == nil || len(c.cfg.RootCtxFuncs) == 0 {
+		return false
+	}
+	for fn.Parent() != nil {
+		fn = fn.Parent()
+	}
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	obj, ok := fn.Object().(*types.Func)
+	return ok && c.cfg.RootCtxFuncs.Contains(typeutil.FuncName(obj))
+}
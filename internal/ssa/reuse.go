@@ -0,0 +1,230 @@
+package ssa
+
+import (
+	"go/token"
+	"slices"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// =============================================================================
+// Events Used After Sending
+// =============================================================================
+
+// eventAlias is an SSA value referring to the Event of a level call.
+type eventAlias struct {
+	v ssa.Value
+	// blocks returns true for instructions after which v no longer refers
+	// to the same Event: its own definition for values computed in a loop,
+	// stores to the local variable it is loaded from, nothing for phis.
+	blocks func(ssa.Instruction) bool
+}
+
+// checkEventReuse reports uses of an Event on a path after it was sent.
+// Msg and Send return the Event to a pool, so later uses corrupt events
+// logged elsewhere:
+//
+//	e := log.Info()
+//	e.Msg("a")
+//	e.Msg("b")               ← reported
+//
+//	e := log.Info().Ctx(ctx)
+//	for _, v := range vs {
+//	    e.Str("v", v).Send()  ← reported: the second iteration reuses e
+//	}
+//
+// The aliases of each level call are its Event methods' results, the loads
+// of local variables it is stored to and the merges (phis) of aliases only. A use of an alias is reported
+// if it can be reached from a terminator on any of them without passing its
+// redefinition (or a new store to its variable). Deferred terminators run on
+// return and are not considered.
+func (c *Checker) checkEventReuse(fn *ssa.Function) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok || !c.isLevelCall(call) {
+				continue
+			}
+			c.checkEventReuseOf(c.eventAliases(call))
+		}
+	}
+}
+
+// checkEventReuseOf checks the uses of one Event after each of its terminators.
+func (c *Checker) checkEventReuseOf(aliases []eventAlias) {
+	for _, term := range aliases {
+		for _, sent := range c.terminatorsOf(term.v) {
+			for _, alias := range aliases {
+				for _, use := range eventUses(alias.v) {
+					if use == ssa.Instruction(sent) || !use.Pos().IsValid() {
+						continue
+					}
+					if reachableAfter(sent, use, alias.blocks) {
+						c.reportMsg(use.Pos(), "zerolog event used after ."+
+							sent.Call.StaticCallee().Name()+"() returned it to the pool")
+					}
+				}
+			}
+		}
+	}
+}
+
+// eventAliases collects the values referring to the Event of a level call.
+// A phi is an alias once all its edges are:
+//
+//	e := log.Info().Ctx(ctx)
+//	if verbose {
+//	    e = e.Str("k", "v")
+//	}
+//	e.Msg("a")               // e = phi [t1, t2]: both the same Event
+func (c *Checker) eventAliases(level *ssa.Call) []eventAlias {
+	var aliases []eventAlias
+	seen := make(map[ssa.Value]bool)
+	var add func(v ssa.Value, blocks func(ssa.Instruction) bool)
+	add = func(v ssa.Value, blocks func(ssa.Instruction) bool) {
+		if seen[v] {
+			return
+		}
+		seen[v] = true
+		aliases = append(aliases, eventAlias{v: v, blocks: blocks})
+		if v.Referrers() == nil {
+			return
+		}
+		for _, ref := range *v.Referrers() {
+			switch r := ref.(type) {
+			case *ssa.Call:
+				// Str, Int, Ctx, ...: the same Event (Discard drops it)
+				recv := r.Call.Signature().Recv()
+				callee := r.Call.StaticCallee()
+				if recv == nil || !c.pkgs.IsEvent(recv.Type()) || len(r.Call.Args) == 0 || r.Call.Args[0] != v ||
+					callee == nil || !c.pkgs.ReturnsEvent(callee) || callee.Name() == "Discard" {
+					continue
+				}
+				add(r, isInstr(r))
+			case *ssa.Store:
+				alloc, ok := r.Addr.(*ssa.Alloc)
+				if !ok || r.Val != v || alloc.Heap {
+					continue
+				}
+				for _, load := range allocLoads(alloc) {
+					add(load, storesTo(alloc))
+				}
+			case *ssa.Phi:
+				// Checked again as each edge is added. The phi only merges
+				// the same Event, so it redefines nothing.
+				if !slices.ContainsFunc(r.Edges, func(edge ssa.Value) bool { return edge != r && !seen[edge] }) {
+					add(r, func(ssa.Instruction) bool { return false })
+				}
+			}
+		}
+	}
+	add(level, isInstr(level))
+	return aliases
+}
+
+// terminatorsOf returns the non-deferred terminator calls on v.
+func (c *Checker) terminatorsOf(v ssa.Value) []*ssa.Call {
+	var calls []*ssa.Call
+	for _, ref := range *v.Referrers() {
+		call, ok := ref.(*ssa.Call)
+		if ok && terminatedEvent(c.pkgs, &call.Call) == v {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// eventUses returns the instructions using v as an operand, except debug
+// information, nil checks and phis: phis of aliases only are aliases, whose
+// own uses are checked, and other phis hold another Event on some path.
+func eventUses(v ssa.Value) []ssa.Instruction {
+	var uses []ssa.Instruction
+	for _, ref := range *v.Referrers() {
+		switch ref.(type) {
+		case *ssa.DebugRef, *ssa.BinOp, *ssa.If, *ssa.Phi:
+			continue
+		}
+		uses = append(uses, ref)
+	}
+	return uses
+}
+
+// allocLoads returns the loads of a local variable.
+func allocLoads(alloc *ssa.Alloc) []ssa.Value {
+	var loads []ssa.Value
+	for _, ref := range *alloc.Referrers() {
+		if load, ok := ref.(*ssa.UnOp); ok && load.Op == token.MUL {
+			loads = append(loads, load)
+		}
+	}
+	return loads
+}
+
+// isInstr returns a predicate matching the instruction defining v.
+func isInstr(v ssa.Instruction) func(ssa.Instruction) bool {
+	return func(instr ssa.Instruction) bool { return instr == v }
+}
+
+// storesTo returns a predicate matching stores to alloc.
+func storesTo(alloc *ssa.Alloc) func(ssa.Instruction) bool {
+	return func(instr ssa.Instruction) bool {
+		store, ok := instr.(*ssa.Store)
+		return ok && store.Addr == alloc
+	}
+}
+
+// reachableAfter returns true if to can be executed after from on some path
+// not passing an instruction matched by blocks.
+func reachableAfter(from, to ssa.Instruction, blocks func(ssa.Instruction) bool) bool {
+	start := from.Block()
+	if start == nil || start.Parent() != to.Block().Parent() {
+		return false
+	}
+
+	// scan walks instrs in order, returning (found, passable)
+	scan := func(instrs []ssa.Instruction) (bool, bool) {
+		for _, instr := range instrs {
+			if instr == to {
+				return true, true
+			}
+			if blocks(instr) {
+				return false, false
+			}
+		}
+		return false, true
+	}
+
+	// The rest of the block of from
+	for i, instr := range start.Instrs {
+		if instr != from {
+			continue
+		}
+		found, passable := scan(start.Instrs[i+1:])
+		if found {
+			return true
+		}
+		if !passable {
+			return false
+		}
+		break
+	}
+
+	visited := make(map[*ssa.BasicBlock]bool)
+	queue := append([]*ssa.BasicBlock(nil), start.Succs...)
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:]
+		if visited[block] {
+			continue
+		}
+		visited[block] = true
+		found, passable := scan(block.Instrs)
+		if found {
+			return true
+		}
+		if passable {
+			queue = append(queue, block.Succs...)
+		}
+	}
+	return false
+}
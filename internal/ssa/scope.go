@@ -0,0 +1,476 @@
+package ssa
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Context Scope
+// =============================================================================
+
+// derivation is a named context derived from another context in scope.
+//
+//	tctx, cancel := context.WithTimeout(ctx, d)   parent: ctx, child: tctx, cancel: cancel
+//	ctx2, span := tracer.Start(ctx, "op")         parent: ctx, child: ctx2
+//	g, gctx := errgroup.WithContext(ctx)          parent: ctx, child: gctx
+//	lctx := logger.WithContext(ctx)               parent: ctx, child: lctx
+//
+// In functions without a context of their own, contexts created in the body
+// are sources as well, recorded without a parent:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	ctx := context.WithoutCancel(parent)
+type derivation struct {
+	instr  ssa.Instruction // Call creating the child
+	parent ssa.Value       // Context passed in (nil for local sources)
+	child  ssa.Value       // Context returned (the call or an Extract of it)
+	cancel ssa.Value       // Cancel function, if any
+	name   string          // Variable the child is assigned to
+}
+
+// scope tracks the contexts derived in a function, so that each log site can
+// be checked against the most-derived context live there.
+type scope struct {
+	fn          *ssa.Function
+	accessors   []string // Configured context accessor methods
+	fields      bool     // Whether context fields of parameters are sources
+	locals      bool     // Whether contexts created in the body are sources
+	derivations []*derivation
+}
+
+// scopeOf returns the context scope of fn, computing it on first use.
+func (c *Checker) scopeOf(fn *ssa.Function) *scope {
+	if s, ok := c.scopes[fn]; ok {
+		return s
+	}
+	s := &scope{
+		fn:        fn,
+		accessors: c.accessors(),
+		fields:    c.cfg != nil && c.cfg.CtxFields,
+		locals:    c.fnCtxName == "",
+	}
+	c.scopes[fn] = s
+
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok {
+				continue
+			}
+			d := c.derivationOf(call)
+			if d == nil {
+				continue
+			}
+			if d.parent == nil || !s.inScope(d.parent) {
+				if !s.locals {
+					continue
+				}
+				d.parent = nil
+			}
+			s.derivations = append(s.derivations, d)
+		}
+	}
+	return s
+}
+
+// derivationOf returns the derivation made by a call assigning exactly one
+// context to a named variable, or nil. Its parent is the context passed in,
+// if any. context.WithoutCancel has no parent: it detaches on purpose, and
+// the parent carries the same values.
+func (c *Checker) derivationOf(call *ssa.Call) *derivation {
+	sig := call.Call.Signature()
+	childIdx, cancelIdx := -1, -1
+	results := sig.Results()
+	for i := range results.Len() {
+		switch t := results.At(i).Type(); {
+		case typeutil.IsContextType(t):
+			if childIdx >= 0 {
+				return nil
+			}
+			childIdx = i
+		case isCancelFunc(t):
+			cancelIdx = i
+		}
+	}
+	if childIdx < 0 {
+		return nil
+	}
+
+	var parent ssa.Value
+	for _, arg := range call.Call.Args {
+		if typeutil.IsContextType(arg.Type()) {
+			parent = arg
+			break
+		}
+	}
+	if callee := call.Call.StaticCallee(); callee != nil && typeutil.IsDetachFunc(callee) {
+		parent = nil
+	}
+
+	d := &derivation{instr: call, parent: parent}
+	if results.Len() == 1 {
+		d.child = call
+	} else {
+		d.child = extractOf(call, childIdx)
+		if cancelIdx >= 0 {
+			d.cancel = extractOf(call, cancelIdx)
+		}
+	}
+	if d.child == nil {
+		return nil
+	}
+	d.name = c.assignedName(call, childIdx)
+	if d.name == "" {
+		return nil
+	}
+	return d
+}
+
+// inScope returns true for contexts available in the function: context
+// parameters, accessor contexts, context fields (with -ctx-fields), captured
+// contexts and contexts derived from them.
+func (s *scope) inScope(v ssa.Value) bool {
+	switch val := ctxVar(v).(type) {
+	case *ssa.Parameter:
+		if val.Parent() != s.fn {
+			return false
+		}
+		t := val.Type()
+		return typeutil.ImplementsContext(t) || typeutil.CtxAccessor(t, s.accessors) != "" ||
+			(s.fields && typeutil.CtxField(t, s.fn.Pkg.Pkg) != "")
+	case *ssa.FreeVar:
+		return true
+	case *ssa.Call:
+		// r.Context(), cmd.Context(), ...
+		if recv := accessorRecv(val, s.accessors); recv != nil {
+			return s.inScope(recv)
+		}
+	case *ssa.UnOp:
+		// h.ctx
+		if fa, ok := val.X.(*ssa.FieldAddr); ok && val.Op == token.MUL {
+			return s.fields && s.inScope(fa.X)
+		}
+	case *ssa.Field:
+		// p.Ctx
+		return s.fields && s.inScope(val.X)
+	case *ssa.Alloc:
+		// Variables captured by closures are spilled: in scope if they hold
+		// an in-scope context
+		if val.Parent() != s.fn || val.Referrers() == nil {
+			return false
+		}
+		for _, ref := range *val.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == val && s.inScope(store.Val) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, d := range s.derivations {
+		if d.child == v {
+			return true
+		}
+	}
+	return false
+}
+
+// liveAt returns the derivations whose child is available at instr: created
+// on every path to instr and not cancelled before it.
+func (s *scope) liveAt(instr ssa.Instruction) []*derivation {
+	var live []*derivation
+	for _, d := range s.derivations {
+		if !dominates(d.instr, instr) || d.cancelledBefore(instr) {
+			continue
+		}
+		live = append(live, d)
+	}
+	return live
+}
+
+// cancelledBefore returns true if the child is cancelled on every path to instr.
+// Deferred cancels run after the function body and do not count.
+func (d *derivation) cancelledBefore(instr ssa.Instruction) bool {
+	if d.cancel == nil || d.cancel.Referrers() == nil {
+		return false
+	}
+	for _, ref := range *d.cancel.Referrers() {
+		call, ok := ref.(*ssa.Call)
+		if ok && call.Call.Value == d.cancel && dominates(call, instr) {
+			return true
+		}
+	}
+	return false
+}
+
+// mostDerived returns the live derivation created last, which (since all of
+// them dominate instr) is dominated by all the others.
+func mostDerived(live []*derivation) *derivation {
+	var best *derivation
+	for _, d := range live {
+		if best == nil || dominates(best.instr, d.instr) {
+			best = d
+		}
+	}
+	return best
+}
+
+// =============================================================================
+// Per-Site Context Names
+// =============================================================================
+
+// nameAt returns the name of the context to suggest at instr: the most-derived
+// live context, or the function's context otherwise. Closures see the
+// contexts live where they are created.
+func (c *Checker) nameAt(fn *ssa.Function, instr ssa.Instruction) string {
+	for fn != nil {
+		if d := mostDerived(c.scopeOf(fn).liveAt(instr)); d != nil {
+			return d.name
+		}
+		instr = makeClosureOf(fn)
+		if instr == nil {
+			break
+		}
+		fn = fn.Parent()
+	}
+	return c.fnCtxName
+}
+
+// checkStaleCtx reports .Ctx(parent) when a context derived from parent is
+// live at the call, including parents captured by closures:
+//
+//	tctx, cancel := context.WithTimeout(ctx, d)
+//	defer cancel()
+//	log.Info().Ctx(ctx).Msg("x")   ← reported: use .Ctx(tctx)
+func (c *Checker) checkStaleCtx(call *ssa.Call) {
+	callee := call.Call.StaticCallee()
+	if callee == nil || !c.isCtxSetter(&call.Call, callee) {
+		return
+	}
+	accessors := c.accessors()
+	ref := refOf(call.Call.Args[len(call.Call.Args)-1], accessors)
+
+	fn, instr := call.Parent(), ssa.Instruction(call)
+	for fn != nil {
+		live := c.scopeOf(fn).liveAt(instr)
+		for _, d := range live {
+			if refOf(d.parent, accessors) != ref {
+				continue
+			}
+			// c.ctxName is the most-derived context live here
+			c.report(call.Pos(), "zerolog .Ctx() is given a parent of the derived context %s",
+				c.ctxArgFixes(&call.Call)...)
+			return
+		}
+
+		// Captured contexts: continue in the enclosing function
+		fv, ok := ref.v.(*ssa.FreeVar)
+		if !ok {
+			return
+		}
+		mc := makeClosureOf(fn)
+		idx := freeVarIndex(fn, fv)
+		if mc == nil || idx < 0 || idx >= len(mc.Bindings) {
+			return
+		}
+		ref.v = ctxVar(mc.Bindings[idx])
+		fn, instr = fn.Parent(), mc
+	}
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// assignedName returns the name of the variable the idx-th result of call is
+// assigned to, or "" if it is not assigned to a named variable.
+func (c *Checker) assignedName(call *ssa.Call, idx int) string {
+	file, expr := c.callExprAt(call.Pos())
+	if expr == nil {
+		return ""
+	}
+	path, _ := astutil.PathEnclosingInterval(file, expr.Pos(), expr.End())
+	for i, n := range path {
+		if n != expr || i+1 >= len(path) {
+			continue
+		}
+		var lhs []ast.Expr
+		switch stmt := path[i+1].(type) {
+		case *ast.AssignStmt:
+			if len(stmt.Rhs) != 1 {
+				return ""
+			}
+			lhs = stmt.Lhs
+		case *ast.ValueSpec:
+			if len(stmt.Values) != 1 {
+				return ""
+			}
+			for _, name := range stmt.Names {
+				lhs = append(lhs, name)
+			}
+		default:
+			return ""
+		}
+		if idx >= len(lhs) {
+			return ""
+		}
+		if id, ok := lhs[idx].(*ast.Ident); ok && id.Name != "_" {
+			return id.Name
+		}
+		return ""
+	}
+	return ""
+}
+
+// ctxVar returns the variable a context is loaded from when it is captured by
+// a closure (spilled to an Alloc, or a FreeVar inside the closure), and v
+// itself otherwise, so that loads of the same variable compare equal.
+// Conversions to context.Context are looked through.
+func ctxVar(v ssa.Value) ssa.Value {
+	switch conv := v.(type) {
+	case *ssa.MakeInterface:
+		v = conv.X
+	case *ssa.ChangeInterface:
+		v = conv.X
+	}
+	if load, ok := v.(*ssa.UnOp); ok && load.Op == token.MUL {
+		switch load.X.(type) {
+		case *ssa.Alloc, *ssa.FreeVar:
+			return load.X
+		}
+	}
+	return v
+}
+
+// ctxRef identifies a context by the variable it is held in. Contexts
+// obtained from an accessor (r.Context()) or a field (h.ctx) are identified
+// by the base variable and the selector, since each call or load is a
+// distinct SSA value.
+type ctxRef struct {
+	v   ssa.Value
+	sel string // "", ".Method()" or ".field"
+}
+
+// refOf returns the ctxRef of a context value.
+func refOf(v ssa.Value, accessors []string) ctxRef {
+	v = ctxVar(v)
+	switch val := v.(type) {
+	case *ssa.Call:
+		if recv := accessorRecv(val, accessors); recv != nil {
+			return ctxRef{v: ctxVar(recv), sel: "." + accessorName(val) + "()"}
+		}
+	case *ssa.UnOp:
+		if fa, ok := val.X.(*ssa.FieldAddr); ok && val.Op == token.MUL {
+			return ctxRef{v: ctxVar(fa.X), sel: "." + typeutil.FieldName(fa.X.Type(), fa.Field)}
+		}
+	case *ssa.Field:
+		return ctxRef{v: ctxVar(val.X), sel: "." + typeutil.FieldName(val.X.Type(), val.Field)}
+	}
+	return ctxRef{v: v}
+}
+
+// accessorRecv returns the receiver of a context accessor call, or nil.
+// Accessors of interfaces (e.g. grpc.ServerStream) are invoked dynamically.
+func accessorRecv(call *ssa.Call, accessors []string) ssa.Value {
+	if call.Call.IsInvoke() {
+		if typeutil.IsCtxAccessor(call.Call.Method, accessors) {
+			return call.Call.Value
+		}
+		return nil
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil || len(call.Call.Args) != 1 {
+		return nil
+	}
+	if fn, ok := callee.Object().(*types.Func); ok && typeutil.IsCtxAccessor(fn, accessors) {
+		return call.Call.Args[0]
+	}
+	return nil
+}
+
+// accessorName returns the method name of an accessor call.
+func accessorName(call *ssa.Call) string {
+	if call.Call.IsInvoke() {
+		return call.Call.Method.Name()
+	}
+	return call.Call.StaticCallee().Name()
+}
+
+// accessors returns the configured context accessor methods.
+func (c *Checker) accessors() []string {
+	if c.cfg == nil {
+		return nil
+	}
+	return c.cfg.CtxAccessors
+}
+
+// dominates returns true if a is executed before b on every path to b.
+// An instruction does not dominate itself.
+func dominates(a, b ssa.Instruction) bool {
+	if a == b || a.Block() == nil || b.Block() == nil || a.Parent() != b.Parent() {
+		return false
+	}
+	if a.Block() != b.Block() {
+		return a.Block().Dominates(b.Block())
+	}
+	for _, instr := range a.Block().Instrs {
+		switch instr {
+		case a:
+			return true
+		case b:
+			return false
+		}
+	}
+	return false
+}
+
+// extractOf returns the Extract of the idx-th result of a tuple-valued call.
+func extractOf(call *ssa.Call, idx int) ssa.Value {
+	if call.Referrers() == nil {
+		return nil
+	}
+	for _, ref := range *call.Referrers() {
+		if ext, ok := ref.(*ssa.Extract); ok && ext.Index == idx {
+			return ext
+		}
+	}
+	return nil
+}
+
+// makeClosureOf returns the MakeClosure creating fn in its parent, if any.
+func makeClosureOf(fn *ssa.Function) *ssa.MakeClosure {
+	parent := fn.Parent()
+	if parent == nil {
+		return nil
+	}
+	for _, block := range parent.Blocks {
+		for _, instr := range block.Instrs {
+			if mc, ok := instr.(*ssa.MakeClosure); ok && mc.Fn == fn {
+				return mc
+			}
+		}
+	}
+	return nil
+}
+
+// freeVarIndex returns the index of fv in fn.FreeVars.
+func freeVarIndex(fn *ssa.Function, fv *ssa.FreeVar) int {
+	for i, v := range fn.FreeVars {
+		if v == fv {
+			return i
+		}
+	}
+	return -1
+}
+
+// isCancelFunc returns true for func() and context.CancelFunc-like types.
+func isCancelFunc(t types.Type) bool {
+	sig, ok := t.Underlying().(*types.Signature)
+	return ok && sig.Results().Len() == 0
+}
@@ -0,0 +1,87 @@
+package ssa
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/config"
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Standard Library Logging
+// =============================================================================
+
+// fixMsgSlogCtx is the fix message for slog calls without a context.
+const fixMsgSlogCtx = "Pass %s to the Context variant"
+
+// checkStdlibLogging reports, with -stdlib-log, logging that bypasses zerolog
+// in functions with a context:
+//
+//	func handler(ctx context.Context) {
+//	    log.Printf("x")          ← reported: use an Event chain with .Ctx(ctx)
+//	    stdLogger.Println("x")   ← reported (*log.Logger)
+//	    slog.Info("x")           ← reported: use slog.InfoContext(ctx, "x")
+//	    slog.InfoContext(ctx, "x")
+//	}
+//
+// -stdlib-log-allow exempts functions or whole packages (e.g. log.Fatal in
+// handlers that never return), and -stdlib-log-deny adds more (e.g.
+// fmt.Fprintln). It shares the reporting path of zerolog's direct logging.
+func (c *Checker) checkStdlibLogging(call *ssa.Call, callee *ssa.Function) {
+	if c.cfg == nil || !c.cfg.StdlibLog {
+		return
+	}
+	if origin := callee.Origin(); origin != nil {
+		callee = origin
+	}
+	fn, ok := callee.Object().(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return
+	}
+	name, path := typeutil.FuncName(fn), fn.Pkg().Path()
+	if listed(c.cfg.StdlibLogAllow, name, path) {
+		return
+	}
+
+	switch kind := typeutil.StdlibLogging(fn); {
+	case kind == typeutil.StdlibSlog:
+		c.reportMsg(call.Pos(),
+			fmt.Sprintf("%s drops the context; use %sContext(%s, ...)", typeutil.ShortFuncName(fn), fn.Name(), c.ctxName),
+			c.slogCtxFixes(call)...)
+	case kind == typeutil.StdlibLog || listed(c.cfg.StdlibLogDeny, name, path):
+		c.reportMsg(call.Pos(),
+			fmt.Sprintf("%s bypasses context; use Event chain with .Ctx(%s)", typeutil.ShortFuncName(fn), c.ctxName))
+	}
+}
+
+// listed returns true if a function or its package path is in list.
+func listed(list config.List, name, path string) bool {
+	return list.Contains(name) || list.Contains(path)
+}
+
+// slogCtxFixes rewrites a slog call to its Context variant:
+//
+//	slog.Info("x", "k", v)   →  slog.InfoContext(ctx, "x", "k", v)
+func (c *Checker) slogCtxFixes(call *ssa.Call) []analysis.SuggestedFix {
+	_, expr := c.callExprAt(call.Pos())
+	if expr == nil {
+		return nil
+	}
+	sel, ok := astutil.Unparen(expr.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf(fixMsgSlogCtx, c.ctxName),
+		TextEdits: []analysis.TextEdit{
+			{Pos: sel.Sel.End(), End: sel.Sel.End(), NewText: []byte("Context")},
+			{Pos: expr.Lparen + 1, End: expr.Lparen + 1, NewText: []byte(c.ctxName + ", ")},
+		},
+	}}
+}
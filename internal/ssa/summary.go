@@ -0,0 +1,627 @@
+package ssa
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/config"
+	"github.com/mpyw/zerologlintctx/internal/directive"
+	"github.com/mpyw/zerologlintctx/internal/facts"
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Function Summaries
+// =============================================================================
+
+// Summaries computes and caches interprocedural summaries of functions.
+//
+// Functions in the package under analysis are summarized on demand by
+// tracing their return values in summary mode. Functions from other
+// packages are resolved through facts exported when those packages were
+// analyzed.
+//
+//	func newEvent(ctx context.Context, l zerolog.Logger) *zerolog.Event {
+//	    return l.Info().Ctx(ctx)       // → ReturnWithCtx
+//	}
+//	func wrap(e *zerolog.Event) *zerolog.Event {
+//	    return e.Str("k", "v")         // → ReturnFromParams [0]
+//	}
+//	func plain(l zerolog.Logger) *zerolog.Event {
+//	    return l.Info()                // → ReturnFromParams [0]
+//	}
+//	func global() *zerolog.Event {
+//	    return log.Info()              // → ReturnWithoutCtx
+//	}
+//
+// Functions without a context are summarized as well by the values they log
+// on behalf of their callers (see consumeFact).
+type Summaries struct {
+	pass     *analysis.Pass
+	pkg      *ssa.Package
+	ctxFuncs map[*ssa.Function]string // Functions checked on their own
+	pkgs     *typeutil.Packages
+	cfg      *config.Config
+	provider map[types.Object]bool // Functions marked //zerologlintctx:provider
+	returns  summaryCache[*facts.ReturnFact]
+	consumes summaryCache[*facts.ConsumeFact]
+	funcs    []*ssa.Function // Source functions, scanned for hooked globals
+	reads    summaryCache[*facts.ReadsCtxFact]
+	hooked   summaryCache[*facts.HookedFact]
+	globals  map[*ssa.Global]bool // Globals assigned a hooked Logger (lazy)
+}
+
+// summaryCache holds one kind of summaries of current package functions.
+type summaryCache[F any] struct {
+	done       map[*ssa.Function]F
+	inProgress map[*ssa.Function]bool
+}
+
+func newSummaryCache[F any]() summaryCache[F] {
+	return summaryCache[F]{
+		done:       make(map[*ssa.Function]F),
+		inProgress: make(map[*ssa.Function]bool),
+	}
+}
+
+// NewSummaries creates a summary cache for the package under analysis.
+// ctxFuncs lists the functions that have a context available; they are
+// checked directly and never summarized as consumers. funcs are the source
+// functions of the package.
+func NewSummaries(
+	pass *analysis.Pass,
+	pkg *ssa.Package,
+	funcs []*ssa.Function,
+	ctxFuncs map[*ssa.Function]string,
+	pkgs *typeutil.Packages,
+	cfg *config.Config,
+) *Summaries {
+	return &Summaries{
+		pass:     pass,
+		pkg:      pkg,
+		ctxFuncs: ctxFuncs,
+		pkgs:     pkgs,
+		cfg:      cfg,
+		provider: markedProviders(pass),
+		returns:  newSummaryCache[*facts.ReturnFact](),
+		consumes: newSummaryCache[*facts.ConsumeFact](),
+		funcs:    funcs,
+		reads:    newSummaryCache[*facts.ReadsCtxFact](),
+		hooked:   newSummaryCache[*facts.HookedFact](),
+	}
+}
+
+// markedProviders collects the functions of the current package marked with
+// //zerologlintctx:provider.
+func markedProviders(pass *analysis.Pass) map[types.Object]bool {
+	marked := make(map[types.Object]bool)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || !directive.IsProvider(fd) {
+				continue
+			}
+			if obj := pass.TypesInfo.Defs[fd.Name]; obj != nil {
+				marked[obj] = true
+			}
+		}
+	}
+	return marked
+}
+
+// ExportFacts summarizes the given functions and exports facts for the
+// exported ones declared in the current package, so that dependent packages
+// can use them. Unexported functions cannot be called from other packages.
+// Results without context are exported as well, so that callers resolve the
+// helper instead of falling back to tracing its receiver.
+func (s *Summaries) ExportFacts(fns []*ssa.Function) {
+	for _, fn := range fns {
+		obj := fn.Object()
+		if obj == nil || obj.Pkg() != s.pass.Pkg || !obj.Exported() {
+			continue
+		}
+		// Providers need no summary: their results always carry a context
+		if s.provider[obj] {
+			s.pass.ExportObjectFact(obj, new(facts.ProviderFact))
+			continue
+		}
+		if fact := s.returnFact(fn); fact != nil {
+			s.pass.ExportObjectFact(obj, fact)
+		}
+		if fact := s.consumeFact(fn); fact != nil {
+			s.pass.ExportObjectFact(obj, fact)
+		}
+		if s.hookAware() {
+			s.exportHookFacts(fn, obj)
+		}
+	}
+	if s.hookAware() {
+		s.exportHookedGlobals()
+	}
+}
+
+// isProvider returns true for functions whose results carry a context:
+// zerolog.Ctx, log.Ctx, hlog.FromRequest, functions listed in -providers and
+// functions marked //zerologlintctx:provider, in this package or (through
+// facts) in others.
+func (s *Summaries) isProvider(fn *ssa.Function) bool {
+	if s.pkgs.IsCtxFunc(fn) {
+		return true
+	}
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	obj, ok := fn.Object().(*types.Func)
+	if !ok || s == nil {
+		return false
+	}
+	if s.cfg != nil && s.cfg.Providers.Contains(typeutil.FuncName(obj)) {
+		return true
+	}
+	if obj.Pkg() == s.pass.Pkg {
+		return s.provider[obj]
+	}
+	return s.pass.ImportObjectFact(obj, new(facts.ProviderFact))
+}
+
+// returnFact returns the return summary of fn, or nil if none is available.
+func (s *Summaries) returnFact(fn *ssa.Function) *facts.ReturnFact {
+	return summarize(s, fn, &s.returns, s.summarizeReturns)
+}
+
+// consumeFact returns the consume summary of fn, or nil if fn logs nothing
+// passed in by its caller without context.
+func (s *Summaries) consumeFact(fn *ssa.Function) *facts.ConsumeFact {
+	return summarize(s, fn, &s.consumes, s.summarizeConsumes)
+}
+
+// summarize looks up or computes a summary of fn.
+//
+// Functions of imported packages have no bodies (they are marked synthetic);
+// their summaries come from facts. Functions of the current package are
+// summarized once and cached. Recursive calls see no summary while it is
+// being built.
+func summarize[F interface {
+	*T
+	analysis.Fact
+}, T any](s *Summaries, fn *ssa.Function, cache *summaryCache[F], compute func(*ssa.Function) F) F {
+	if s == nil || s.pkgs.IsZerologFunc(fn) {
+		return nil
+	}
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+
+	if fn.Package() != s.pkg {
+		obj := fn.Object()
+		if obj == nil {
+			return nil
+		}
+		fact := F(new(T))
+		if !s.pass.ImportObjectFact(obj, fact) {
+			return nil
+		}
+		return fact
+	}
+
+	if fn.Synthetic != "" {
+		return nil
+	}
+	if fact, ok := cache.done[fn]; ok {
+		return fact
+	}
+	if cache.inProgress[fn] {
+		return nil
+	}
+	cache.inProgress[fn] = true
+	fact := compute(fn)
+	delete(cache.inProgress, fn)
+	cache.done[fn] = fact
+	return fact
+}
+
+// summarizeReturns builds the return summary of a function in the current package.
+//
+// Each zerolog-typed result is traced from every return statement. Reaching
+// one of the function's own zerolog-typed parameters is recorded as a
+// dependency instead of a failure, so that callers can substitute their
+// arguments:
+//
+//	all paths find ctx, no params reached  → ReturnWithCtx
+//	all paths find ctx or reach params     → ReturnFromParams
+//	any path without ctx                   → ReturnWithoutCtx
+func (s *Summaries) summarizeReturns(fn *ssa.Function) *facts.ReturnFact {
+	results := fn.Signature.Results()
+	if results.Len() == 0 || len(fn.Blocks) == 0 {
+		return nil
+	}
+
+	fact := &facts.ReturnFact{Results: make([]facts.Result, results.Len())}
+	hasZerolog := false
+	for i := range results.Len() {
+		t, ok := tracerFor(s.pkgs, results.At(i).Type())
+		if !ok {
+			continue
+		}
+		hasZerolog = true
+		fact.Results[i] = s.summarizeResult(fn, i, t)
+	}
+	if !hasZerolog {
+		return nil
+	}
+	return fact
+}
+
+// summarizeResult summarizes the i-th result of fn.
+func (s *Summaries) summarizeResult(fn *ssa.Function, i int, t tracerType) facts.Result {
+	chk := newSummaryChecker(s, fn)
+	hasReturn := false
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			ret, ok := instr.(*ssa.Return)
+			if !ok || i >= len(ret.Results) {
+				continue
+			}
+			// Returning nil carries nothing that could be logged.
+			if isNilConst(ret.Results[i]) {
+				continue
+			}
+			hasReturn = true
+			if !chk.traceValue(ret.Results[i], t, make(map[ssa.Value]bool)) {
+				return facts.Result{Ctx: facts.ReturnWithoutCtx}
+			}
+		}
+	}
+	if !hasReturn {
+		return facts.Result{}
+	}
+	if len(chk.hits) == 0 {
+		return facts.Result{Ctx: facts.ReturnWithCtx}
+	}
+	params := make([]int, 0, len(chk.hits))
+	for _, hit := range chk.hits {
+		// Results read from parameter fields are not substituted at call sites.
+		if len(hit.Fields) > 0 {
+			return facts.Result{Ctx: facts.ReturnWithoutCtx}
+		}
+		params = append(params, hit.Param)
+	}
+	slices.Sort(params)
+	return facts.Result{Ctx: facts.ReturnFromParams, Params: params}
+}
+
+// summarizeConsumes builds the consume summary of a function in the current package.
+//
+// Only functions without a context are summarized: functions with one are
+// checked directly, so their callers need not care. The same holds for sites
+// where a context created in fn is live (see Checker.nameAt). Every other
+// event terminated in fn, directly or by another consumer it calls, is traced in summary
+// mode. If the trace succeeds by reaching parameters (or their fields), the
+// caller is responsible for passing values with context:
+//
+//	func finish(e *zerolog.Event) { e.Msg("x") }              → consumes(0)
+//	func (h *holder) log()        { h.e.Msg("x") }            → consumes(0.0)
+//	func finishCtx(e *zerolog.Event) { e.Ctx(bg).Msg("x") }   → (no fact)
+func (s *Summaries) summarizeConsumes(fn *ssa.Function) *facts.ConsumeFact {
+	if _, ok := s.ctxFuncs[fn]; ok || len(fn.Blocks) == 0 {
+		return nil
+	}
+
+	chk := newSummaryChecker(s, fn)
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(ssa.CallInstruction)
+			if !ok || chk.nameAt(fn, instr) != "" {
+				continue
+			}
+			if ev := terminatedEvent(s.pkgs, call.Common()); ev != nil {
+				chk.withHits(func() bool {
+					return chk.traceValue(ev, tracerEvent, make(map[ssa.Value]bool))
+				})
+				continue
+			}
+			chk.consumerArgsHaveCtx(call.Common())
+		}
+	}
+	if len(chk.hits) == 0 {
+		return nil
+	}
+	return &facts.ConsumeFact{Paths: chk.hits}
+}
+
+// newSummaryChecker creates a checker that records parameter dependencies
+// instead of reporting diagnostics.
+func newSummaryChecker(s *Summaries, fn *ssa.Function) *Checker {
+	return &Checker{
+		pass:      s.pass,
+		cfg:       s.cfg,
+		pkgs:      s.pkgs,
+		summaries: s,
+		summaryOf: fn,
+		scopes:    make(map[*ssa.Function]*scope),
+	}
+}
+
+// terminatedEvent returns the Event terminated by a call (Msg, Msgf, MsgFunc,
+// Send), including bound method calls, or nil if the call is not a terminator.
+func terminatedEvent(pkgs *typeutil.Packages, common *ssa.CallCommon) ssa.Value {
+	callee := common.StaticCallee()
+	if callee == nil || !typeutil.ReturnsVoid(callee) {
+		return nil
+	}
+	if mc, ok := common.Value.(*ssa.MakeClosure); ok {
+		if len(mc.Bindings) > 0 && pkgs.IsEvent(mc.Bindings[0].Type()) {
+			return mc.Bindings[0]
+		}
+		return nil
+	}
+	recv := common.Signature().Recv()
+	if recv == nil || !pkgs.IsEvent(recv.Type()) || len(common.Args) == 0 {
+		return nil
+	}
+	return common.Args[0]
+}
+
+// =============================================================================
+// Summary Application
+// =============================================================================
+
+// traceSummarizedCall traces a call to a summarized function.
+// handled is false when no summary is available for the callee.
+func (c *Checker) traceSummarizedCall(
+	call *ssa.Call,
+	callee *ssa.Function,
+	index int,
+	visited map[ssa.Value]bool,
+) (found, handled bool) {
+	fact := c.summaries.returnFact(callee)
+	if fact == nil {
+		return false, false
+	}
+
+	result := fact.Result(index)
+	switch result.Ctx {
+	case facts.ReturnWithCtx:
+		return true, true
+	case facts.ReturnFromParams:
+		for _, p := range result.Params {
+			pt, ok := tracerFor(c.pkgs, paramType(callee.Signature, p))
+			if !ok || p >= len(call.Call.Args) {
+				return false, true
+			}
+			argVisited := maps.Clone(visited)
+			if !c.traceValue(call.Call.Args[p], pt, argVisited) {
+				return false, true
+			}
+		}
+		return true, true
+	case facts.ReturnWithoutCtx:
+		return false, true
+	}
+	return false, false
+}
+
+// consumerArgsHaveCtx checks the arguments of a call to a consumer, a
+// function logging values passed in by its caller (see summarizeConsumes).
+// It returns false only if some consumed argument is known to lack context.
+func (c *Checker) consumerArgsHaveCtx(common *ssa.CallCommon) bool {
+	callee := common.StaticCallee()
+	if callee == nil {
+		return true
+	}
+	fact := c.summaries.consumeFact(callee)
+	if fact == nil {
+		return true
+	}
+	for _, path := range fact.Paths {
+		if path.Param >= len(common.Args) {
+			continue
+		}
+		known := false
+		found := c.withHits(func() bool {
+			var ok bool
+			ok, known = c.tracePath(common.Args[path.Param], path.Fields)
+			return ok
+		})
+		if known && !found && c.needsCtx(common.Args[path.Param]) {
+			return false
+		}
+	}
+	return true
+}
+
+// tracePath traces the zerolog value found by following struct fields from v.
+// known is false when the value cannot be determined, e.g. fields of values
+// not constructed in the current function.
+//
+//	h := &holder{e: logger.Info()}   // Store to &h.e
+//	h.log()                          // tracePath(h, [0]) traces logger.Info()
+func (c *Checker) tracePath(v ssa.Value, fields []int) (found, known bool) {
+	if len(fields) == 0 {
+		t, ok := tracerFor(c.pkgs, v.Type())
+		if !ok {
+			return false, false
+		}
+		return c.traceValue(v, t, make(map[ssa.Value]bool)), true
+	}
+
+	// In summary mode, fields of own parameters become dependencies
+	if p, ok := v.(*ssa.Parameter); ok && c.summaryOf != nil && p.Parent() == c.summaryOf {
+		if idx := paramIndex(c.summaryOf, p); idx >= 0 {
+			c.addHit(facts.ParamPath{Param: idx, Fields: fields})
+			return true, true
+		}
+	}
+
+	// Structs passed by value are loaded from their allocation
+	addr := v
+	if load, ok := v.(*ssa.UnOp); ok && load.Op == token.MUL {
+		addr = load.X
+	}
+	if _, ok := addr.Type().Underlying().(*types.Pointer); !ok {
+		return false, false
+	}
+
+	for _, stored := range storesToField(addr, fields[0]) {
+		f, k := c.tracePath(stored, fields[1:])
+		if !k {
+			continue
+		}
+		if !f {
+			return false, true
+		}
+		known = true
+	}
+	return known, known
+}
+
+// storesToField returns the values stored to the given field of a struct pointer.
+func storesToField(addr ssa.Value, field int) []ssa.Value {
+	refs := addr.Referrers()
+	if refs == nil {
+		return nil
+	}
+	var stored []ssa.Value
+	for _, ref := range *refs {
+		fa, ok := ref.(*ssa.FieldAddr)
+		if !ok || fa.X != addr || fa.Field != field || fa.Referrers() == nil {
+			continue
+		}
+		for _, far := range *fa.Referrers() {
+			if store, ok := far.(*ssa.Store); ok && store.Addr == fa {
+				stored = append(stored, store.Val)
+			}
+		}
+	}
+	return stored
+}
+
+// traceParameter handles a function parameter reached during tracing.
+// In summary mode, zerolog-typed parameters are recorded as dependencies.
+func (c *Checker) traceParameter(p *ssa.Parameter) bool {
+	if c.summaryOf == nil || p.Parent() != c.summaryOf {
+		return false
+	}
+	if _, ok := tracerFor(c.pkgs, p.Type()); !ok {
+		return false
+	}
+	if idx := paramIndex(c.summaryOf, p); idx >= 0 {
+		c.addHit(facts.ParamPath{Param: idx})
+		return true
+	}
+	return false
+}
+
+// traceParamField handles a zerolog-typed field of a parameter reached during
+// tracing. In summary mode, it is recorded as a dependency:
+//
+//	func (h *holder) log() {
+//	    h.e.Msg("x")   // t1 = &h.e; t2 = *t1 → ParamPath{Param: 0, Fields: [0]}
+//	}
+func (c *Checker) traceParamField(v ssa.Value) bool {
+	if c.summaryOf == nil {
+		return false
+	}
+	var fields []int
+	for {
+		switch val := v.(type) {
+		case *ssa.FieldAddr:
+			fields = append(fields, val.Field)
+			v = val.X
+		case *ssa.Field:
+			fields = append(fields, val.Field)
+			v = val.X
+		case *ssa.UnOp:
+			// Intermediate pointer loads, unless reassigned locally
+			if val.Op != token.MUL || len(findAllStoredValues(val.X)) > 0 {
+				return false
+			}
+			v = val.X
+		case *ssa.Alloc:
+			// Value receivers and parameters are spilled to a local
+			stored := findAllStoredValues(val)
+			if len(stored) != 1 {
+				return false
+			}
+			if _, ok := stored[0].(*ssa.Parameter); !ok {
+				return false
+			}
+			v = stored[0]
+		case *ssa.Parameter:
+			idx := paramIndex(c.summaryOf, val)
+			if idx < 0 || len(fields) == 0 {
+				return false
+			}
+			slices.Reverse(fields)
+			c.addHit(facts.ParamPath{Param: idx, Fields: fields})
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// withHits runs a trace in summary mode, keeping the dependencies it records
+// only if it succeeds.
+func (c *Checker) withHits(trace func() bool) bool {
+	saved := c.hits
+	c.hits = nil
+	ok := trace()
+	pending := c.hits
+	c.hits = saved
+	if ok {
+		for _, hit := range pending {
+			c.addHit(hit)
+		}
+	}
+	return ok
+}
+
+// addHit records a parameter dependency once.
+func (c *Checker) addHit(path facts.ParamPath) {
+	for _, hit := range c.hits {
+		if hit.Param == path.Param && slices.Equal(hit.Fields, path.Fields) {
+			return
+		}
+	}
+	c.hits = append(c.hits, path)
+}
+
+// paramIndex returns the index of p in fn.Params, or -1.
+func paramIndex(fn *ssa.Function, p *ssa.Parameter) int {
+	return slices.Index(fn.Params, p)
+}
+
+// tracerFor returns the tracer type matching a zerolog type.
+func tracerFor(pkgs *typeutil.Packages, t types.Type) (tracerType, bool) {
+	switch {
+	case pkgs.IsEvent(t):
+		return tracerEvent, true
+	case pkgs.IsLogger(t):
+		return tracerLogger, true
+	case pkgs.IsContext(t):
+		return tracerContext, true
+	}
+	return 0, false
+}
+
+// paramType returns the type of the i-th parameter of sig, counting the
+// receiver (if any) as parameter 0, matching the layout of ssa.CallCommon.Args.
+func paramType(sig *types.Signature, i int) types.Type {
+	if recv := sig.Recv(); recv != nil {
+		if i == 0 {
+			return recv.Type()
+		}
+		i--
+	}
+	if i < 0 || i >= sig.Params().Len() {
+		return nil
+	}
+	return sig.Params().At(i).Type()
+}
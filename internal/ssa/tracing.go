@@ -64,6 +64,7 @@ type checkResult struct {
 //	│     │           │                                                │
 //	│     │           ├─ Found → return true                          │
 //	│     │           ├─ Delegate → traceValue(delegateVal, newTracer)│
+//	│     │           ├─ Summarized helper → apply summary            │
 //	│     │           └─ Continue → trace receiver if type matches    │
 //	│     │                                                            │
 //	│     └─ Not a Call → traceCommon (Phi, UnOp, Alloc, etc.)        │
@@ -79,11 +80,25 @@ func (c *Checker) traceValue(v ssa.Value, t tracerType, visited map[ssa.Value]bo
 		return c.traceCommon(v, visited, t)
 	}
 
+	return c.traceCall(call, 0, visited, t)
+}
+
+// traceCall traces the index-th result of a call.
+// The index only matters for summarized helper functions with multiple results.
+func (c *Checker) traceCall(call *ssa.Call, index int, visited map[ssa.Value]bool, t tracerType) bool {
 	callee := call.Call.StaticCallee()
 	if callee == nil {
 		return c.traceReceiver(call, visited, t)
 	}
 
+	// Root mode: zerolog.New(w), log.With(), ... (see ctxlogger.go)
+	if c.rootMode {
+		if root := c.newLoggerRoot(callee); root != "" {
+			c.roots = append(c.roots, root)
+			return true
+		}
+	}
+
 	// Check if this is an IIFE (Immediately Invoked Function Expression)
 	if _, ok := call.Call.Value.(*ssa.MakeClosure); ok {
 		if c.traceIIFEReturns(callee, visited, t) {
@@ -102,6 +117,16 @@ func (c *Checker) traceValue(v ssa.Value, t tracerType, visited map[ssa.Value]bo
 		return c.traceValue(result.delegateVal, result.delegateTo, visited)
 	}
 
+	// Helper functions: apply the callee's summary (see summary.go). Summaries
+	// do not tell Context.Ctx from providers, so escape mode skips them, and
+	// root mode does not look into helpers.
+	if (c.escapeMode || c.rootMode) && !c.pkgs.IsZerologFunc(callee) {
+		return false
+	}
+	if found, handled := c.traceSummarizedCall(call, callee, index, visited); handled {
+		return found
+	}
+
 	// Continue tracing through receiver if type matches
 	if c.shouldContinueOnReceiver(recv, t) {
 		return c.traceReceiver(call, visited, t)
@@ -145,25 +170,26 @@ func (c *Checker) checkContextForEvent(
 ) checkResult {
 	// Event.Ctx(ctx) or Context.Ctx(ctx) - direct context setting
 	if callee.Name() == typeutil.CtxMethod && recv != nil {
-		if typeutil.IsEvent(recv.Type()) || typeutil.IsContext(recv.Type()) {
+		if c.pkgs.IsEvent(recv.Type()) || c.pkgs.IsContext(recv.Type()) {
 			return checkResult{found: true}
 		}
 	}
 
-	// zerolog.Ctx(ctx) - returns Logger with context
-	if typeutil.IsCtxFunc(callee) {
+	// zerolog.Ctx(ctx) and other providers - return a Logger or Event with
+	// context, unless strict mode requires Context.Ctx
+	if !c.strict() && !c.escapeMode && !c.rootMode && c.summaries.isProvider(callee) {
 		return checkResult{found: true}
 	}
 
 	// Logger methods that return Event - delegate to logger tracer
-	if recv != nil && typeutil.IsLogger(recv.Type()) && typeutil.ReturnsEvent(callee) {
+	if recv != nil && c.pkgs.IsLogger(recv.Type()) && c.pkgs.ReturnsEvent(callee) {
 		if len(call.Call.Args) > 0 {
 			return checkResult{delegate: true, delegateTo: tracerLogger, delegateVal: call.Call.Args[0]}
 		}
 	}
 
 	// Context methods that return Logger - delegate to context tracer
-	if recv != nil && typeutil.IsContext(recv.Type()) && typeutil.ReturnsLogger(callee) {
+	if recv != nil && c.pkgs.IsContext(recv.Type()) && c.pkgs.ReturnsLogger(callee) {
 		if len(call.Call.Args) > 0 {
 			return checkResult{delegate: true, delegateTo: tracerContext, delegateVal: call.Call.Args[0]}
 		}
@@ -178,20 +204,21 @@ func (c *Checker) checkContextForLogger(
 	callee *ssa.Function,
 	recv *types.Var,
 ) checkResult {
-	// zerolog.Ctx(ctx) - returns Logger with context
-	if typeutil.IsCtxFunc(callee) {
+	// zerolog.Ctx(ctx) and other providers - return a Logger or Event with
+	// context, unless strict mode requires Context.Ctx
+	if !c.strict() && !c.escapeMode && !c.rootMode && c.summaries.isProvider(callee) {
 		return checkResult{found: true}
 	}
 
 	// Context methods that return Logger - delegate to context tracer
-	if recv != nil && typeutil.IsContext(recv.Type()) && typeutil.ReturnsLogger(callee) {
+	if recv != nil && c.pkgs.IsContext(recv.Type()) && c.pkgs.ReturnsLogger(callee) {
 		if len(call.Call.Args) > 0 {
 			return checkResult{delegate: true, delegateTo: tracerContext, delegateVal: call.Call.Args[0]}
 		}
 	}
 
 	// Logger.With() returns Context - continue tracing parent Logger
-	if recv != nil && typeutil.IsLogger(recv.Type()) && typeutil.ReturnsContext(callee) {
+	if recv != nil && c.pkgs.IsLogger(recv.Type()) && c.pkgs.ReturnsContext(callee) {
 		if len(call.Call.Args) > 0 {
 			return checkResult{delegate: true, delegateTo: tracerLogger, delegateVal: call.Call.Args[0]}
 		}
@@ -206,13 +233,13 @@ func (c *Checker) checkContextForContext(
 	callee *ssa.Function,
 	recv *types.Var,
 ) checkResult {
-	// Context.Ctx(ctx) - direct context setting
-	if callee.Name() == typeutil.CtxMethod && recv != nil && typeutil.IsContext(recv.Type()) {
+	// Context.Ctx(ctx) - direct context setting, followed to the logger in root mode
+	if !c.rootMode && callee.Name() == typeutil.CtxMethod && recv != nil && c.pkgs.IsContext(recv.Type()) {
 		return checkResult{found: true}
 	}
 
 	// Logger.With() returns Context - delegate to logger tracer
-	if recv != nil && typeutil.IsLogger(recv.Type()) && typeutil.ReturnsContext(callee) {
+	if recv != nil && c.pkgs.IsLogger(recv.Type()) && c.pkgs.ReturnsContext(callee) {
 		if len(call.Call.Args) > 0 {
 			return checkResult{delegate: true, delegateTo: tracerLogger, delegateVal: call.Call.Args[0]}
 		}
@@ -229,11 +256,11 @@ func (c *Checker) shouldContinueOnReceiver(recv *types.Var, t tracerType) bool {
 	}
 	switch t {
 	case tracerEvent:
-		return typeutil.IsEvent(recv.Type())
+		return c.pkgs.IsEvent(recv.Type())
 	case tracerLogger:
-		return typeutil.IsLogger(recv.Type())
+		return c.pkgs.IsLogger(recv.Type())
 	case tracerContext:
-		return typeutil.IsContext(recv.Type())
+		return c.pkgs.IsContext(recv.Type())
 	}
 	return false
 }
@@ -253,6 +280,33 @@ func (c *Checker) traceCommon(v ssa.Value, visited map[ssa.Value]bool, t tracerT
 		return c.traceAlloc(val, visited, t)
 	case *ssa.FreeVar:
 		return c.traceFreeVar(val, visited, t)
+	case *ssa.Parameter:
+		return c.traceParameter(val)
+	case *ssa.FieldAddr, *ssa.Field:
+		if c.traceParamField(val) {
+			return true
+		}
+	case *ssa.Extract:
+		if call, ok := val.Tuple.(*ssa.Call); ok && !visited[call] {
+			visited[call] = true
+			return c.traceCall(call, val.Index, visited, t)
+		}
+		if sel, ok := val.Tuple.(*ssa.Select); ok {
+			return c.traceSelectRecv(sel, val.Index, visited, t)
+		}
+		if next, ok := val.Tuple.(*ssa.Next); ok {
+			return c.traceRangeValue(next, val.Index, visited, t)
+		}
+	case *ssa.Index:
+		return c.traceElement(val.X, visited, t)
+	case *ssa.Lookup:
+		return c.traceElement(val.X, visited, t)
+	case *ssa.Global:
+		// Root mode: package-level loggers (see ctxlogger.go)
+		if c.rootMode {
+			c.roots = append(c.roots, qualifiedName(val.Pkg.Pkg, val.Name()))
+			return true
+		}
 	}
 
 	// Handle simple wrapper types that just need inner value tracing
@@ -372,15 +426,121 @@ func edgeLeadsToImpl(v ssa.Value, target *ssa.Phi, seen map[ssa.Value]bool) bool
 
 // traceUnOp handles SSA unary operations, especially pointer dereferences.
 func (c *Checker) traceUnOp(unop *ssa.UnOp, visited map[ssa.Value]bool, t tracerType) bool {
+	if unop.Op == token.ARROW {
+		return c.traceReceive(unop.X, visited, t)
+	}
 	if unop.Op == token.MUL {
+		if t == tracerLogger && !c.rootMode && c.updatedWithCtx(unop.X, unop) {
+			return true
+		}
 		storedValues := findAllStoredValues(unop.X)
 		if len(storedValues) > 0 {
 			return c.traceAllStoredValues(storedValues, visited, t)
 		}
+		// Elements of slices and arrays: every value written to them
+		if ia, ok := unop.X.(*ssa.IndexAddr); ok {
+			return c.traceElement(ia.X, visited, t)
+		}
 	}
 	return c.traceValue(unop.X, t, visited)
 }
 
+// updatedWithCtx returns true if the Logger at addr was given a context in
+// place by Logger.UpdateContext before load:
+//
+//	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+//	    return c.Ctx(ctx)
+//	})
+//	l.Info().Msg("x")   // t0 = *l → UpdateContext(l, ...) dominates the load
+//
+// The update must happen on every path to the load, with no assignment to
+// the logger in between on any path (otherwise every stored value is traced), and every return of the callback must be a Context
+// chain with .Ctx().
+func (c *Checker) updatedWithCtx(addr ssa.Value, load *ssa.UnOp) bool {
+	fn := load.Parent()
+	if fn == nil {
+		return false
+	}
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok || !c.isUpdateContext(call) || !addressesMatch(call.Call.Args[0], addr) {
+				continue
+			}
+			if !dominates(call, load) || reassignedBetween(addr, call, load) {
+				continue
+			}
+			if c.updateSetsCtx(call.Call.Args[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isUpdateContext returns true for calls to Logger.UpdateContext.
+func (c *Checker) isUpdateContext(call *ssa.Call) bool {
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Name() != "UpdateContext" || len(call.Call.Args) != 2 {
+		return false
+	}
+	recv := call.Call.Signature().Recv()
+	return recv != nil && c.pkgs.IsLogger(recv.Type())
+}
+
+// reassignedBetween returns true if a store to addr can run between update
+// and load on some path, discarding the updated logger:
+//
+//	l.UpdateContext(...)
+//	if cond { l = zerolog.New(os.Stderr) }   ← reaches the load on one path
+//	l.Info().Msg("x")
+//
+// Paths from the store back through update do not count: the logger is
+// updated again before the load.
+func reassignedBetween(addr ssa.Value, update *ssa.Call, load *ssa.UnOp) bool {
+	never := func(ssa.Instruction) bool { return false }
+	for _, block := range load.Parent().Blocks {
+		for _, instr := range block.Instrs {
+			store, ok := instr.(*ssa.Store)
+			if !ok || !addressesMatch(store.Addr, addr) {
+				continue
+			}
+			if reachableAfter(update, store, never) &&
+				reachableAfter(store, load, isInstr(update)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// updateSetsCtx returns true if every return of an UpdateContext callback
+// traces to Context.Ctx.
+func (c *Checker) updateSetsCtx(cb ssa.Value) bool {
+	var fn *ssa.Function
+	switch v := cb.(type) {
+	case *ssa.Function:
+		fn = v
+	case *ssa.MakeClosure:
+		fn, _ = v.Fn.(*ssa.Function)
+	}
+	if fn == nil {
+		return false
+	}
+	hasReturn := false
+	for _, block := range fn.Blocks {
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		hasReturn = true
+		if !c.traceValue(ret.Results[0], tracerContext, make(map[ssa.Value]bool)) {
+			return false
+		}
+	}
+	return hasReturn
+}
+
 // traceAlloc handles SSA Alloc nodes (local variable allocation).
 func (c *Checker) traceAlloc(alloc *ssa.Alloc, visited map[ssa.Value]bool, t tracerType) bool {
 	storedValues := findAllStoredValues(alloc)
@@ -462,8 +622,7 @@ func (c *Checker) traceIIFEReturns(fn *ssa.Function, visited map[ssa.Value]bool,
 	}
 
 	// Only trace if return type is Event, Logger, or Context
-	retType := results.At(0).Type()
-	if !typeutil.IsEvent(retType) && !typeutil.IsLogger(retType) && !typeutil.IsContext(retType) {
+	if !c.pkgs.IsZerologValue(results.At(0).Type()) {
 		return false
 	}
 
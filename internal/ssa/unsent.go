@@ -0,0 +1,151 @@
+package ssa
+
+import (
+	"go/token"
+
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/mpyw/zerologlintctx/internal/typeutil"
+)
+
+// =============================================================================
+// Unsent Events
+// =============================================================================
+
+// checkUnsentEvents reports Events created by a level call that are never
+// sent. Such events are silently dropped, and the pooled Event leaks:
+//
+//	logger.Info().Str("k", "v")            ← reported: no Msg/Send
+//	e := logger.Warn(); e.Int("n", 1)      ← reported
+//	logger.Info().Send()                   ← OK
+//	logger.Debug().Discard()               ← OK: dropped on purpose
+//	return logger.Info()                   ← OK: sent by the caller
+//
+// Unlike the other checks, it runs forwards: the uses of each level call
+// are followed through Event methods, Phi nodes and local variables (stores
+// and loads, the way tracing.go follows them backwards). An event is
+// reported only if no path reaches a terminator (Msg, Msgf, MsgFunc, Send),
+// Discard, or a use the checker cannot follow: returning it, storing it
+// elsewhere, passing it to a function or capturing it.
+func (c *Checker) checkUnsentEvents(fn *ssa.Function) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok || !c.isLevelCall(call) {
+				continue
+			}
+			if c.eventHandled(call, make(map[ssa.Value]bool)) {
+				continue
+			}
+			c.reportMsg(call.Pos(), "zerolog event from "+call.Call.StaticCallee().Name()+
+				"() is never sent; end the chain with .Msg() or .Send()")
+		}
+	}
+}
+
+// isLevelCall returns true for calls creating an Event from a logger:
+// Logger.Info(), Logger.WithLevel(), log.Info(), ...
+func (c *Checker) isLevelCall(call *ssa.Call) bool {
+	callee := call.Call.StaticCallee()
+	if callee == nil || !c.pkgs.IsZerologFunc(callee) || !c.pkgs.ReturnsEvent(callee) {
+		return false
+	}
+	if recv := call.Call.Signature().Recv(); recv != nil {
+		return c.pkgs.IsLogger(recv.Type())
+	}
+	return c.pkgs.PackageKind(callee.Package().Pkg.Path()) == typeutil.PkgLog
+}
+
+// eventHandled returns true if some use of the Event v sends, discards or
+// hands it off.
+func (c *Checker) eventHandled(v ssa.Value, visited map[ssa.Value]bool) bool {
+	if visited[v] {
+		return false
+	}
+	visited[v] = true
+
+	refs := v.Referrers()
+	if refs == nil {
+		return false
+	}
+	for _, ref := range *refs {
+		switch r := ref.(type) {
+		case *ssa.DebugRef, *ssa.BinOp, *ssa.If:
+			// Debug info and nil checks neither send nor hand off
+		case ssa.CallInstruction:
+			if c.eventCallHandled(r, v, visited) {
+				return true
+			}
+		case *ssa.Phi:
+			if c.eventHandled(r, visited) {
+				return true
+			}
+		case *ssa.Store:
+			if r.Val != v {
+				continue
+			}
+			// Local variables: follow their loads
+			if alloc, ok := r.Addr.(*ssa.Alloc); ok && !alloc.Heap {
+				if c.allocHandled(alloc, visited) {
+					return true
+				}
+				continue
+			}
+			return true
+		default:
+			// Returned, captured, converted, sent on a channel, ...
+			return true
+		}
+	}
+	return false
+}
+
+// eventCallHandled handles a call using the Event v in eventHandled.
+func (c *Checker) eventCallHandled(call ssa.CallInstruction, v ssa.Value, visited map[ssa.Value]bool) bool {
+	common := call.Common()
+	callee := common.StaticCallee()
+	recv := common.Signature().Recv()
+	isRecv := recv != nil && c.pkgs.IsEvent(recv.Type()) && len(common.Args) > 0 && common.Args[0] == v
+	if callee == nil || !c.pkgs.IsZerologFunc(callee) || !isRecv {
+		// Passed to another function, or an Event argument of a zerolog
+		// method (Event.Dict): handed off
+		return true
+	}
+	switch {
+	case typeutil.ReturnsVoid(callee), callee.Name() == "Discard":
+		return true
+	case c.pkgs.ReturnsEvent(callee):
+		// Str, Int, Ctx, ...: follow the returned Event
+		if value := call.Value(); value != nil {
+			return c.eventHandled(value, visited)
+		}
+		// Event methods in go/defer statements
+		return true
+	}
+	// Enabled() and other queries
+	return false
+}
+
+// allocHandled follows the loads of a local variable holding an Event.
+func (c *Checker) allocHandled(alloc *ssa.Alloc, visited map[ssa.Value]bool) bool {
+	if visited[alloc] {
+		return false
+	}
+	visited[alloc] = true
+
+	for _, ref := range *alloc.Referrers() {
+		switch r := ref.(type) {
+		case *ssa.UnOp:
+			if r.Op == token.MUL && c.eventHandled(r, visited) {
+				return true
+			}
+		case *ssa.Store:
+			// Stores of other values
+		case *ssa.DebugRef:
+		default:
+			// Address taken
+			return true
+		}
+	}
+	return false
+}
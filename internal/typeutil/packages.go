@@ -0,0 +1,92 @@
+package typeutil
+
+import (
+	"go/types"
+	"strings"
+)
+
+// =============================================================================
+// Package Table
+// =============================================================================
+
+// PkgKind classifies the packages the type predicates recognize.
+//
+//	github.com/rs/zerolog        PkgCore  Logger, Event, Context, zerolog.Ctx
+//	github.com/rs/zerolog/log    PkgLog   global logger: log.Ctx, log.Info, log.Print
+//	github.com/rs/zerolog/hlog   PkgHlog  net/http helpers: hlog.FromRequest
+type PkgKind uint8
+
+const (
+	PkgCore PkgKind = iota + 1
+	PkgLog
+	PkgHlog
+)
+
+// Packages maps package paths to their kind. Forks are added as core
+// packages along with their log and hlog subpackages. It is built once per
+// pass from the configuration and shared by all checks.
+type Packages struct {
+	kinds map[string]PkgKind
+	roots []string // Core package paths; their subpackages belong to zerolog
+}
+
+// NewPackages returns the recognized packages: github.com/rs/zerolog, the
+// given forks (e.g. github.com/ourorg/zerolog), and extra packages shaped
+// like zerolog/log that wrap a global logger.
+func NewPackages(forks, logPkgs []string) *Packages {
+	p := &Packages{kinds: make(map[string]PkgKind)}
+	for _, root := range append([]string{zerologPkgPath}, forks...) {
+		p.kinds[root] = PkgCore
+		p.kinds[root+"/log"] = PkgLog
+		p.kinds[root+"/hlog"] = PkgHlog
+		p.roots = append(p.roots, root)
+	}
+	for _, path := range logPkgs {
+		p.kinds[path] = PkgLog
+	}
+	return p
+}
+
+// PackageKind returns the kind of a package path, or 0 if it is not a
+// recognized zerolog package.
+func (p *Packages) PackageKind(path string) PkgKind {
+	return p.kinds[path]
+}
+
+// IsZerologPackage returns true for the zerolog packages, their subpackages
+// and the configured log packages.
+func (p *Packages) IsZerologPackage(path string) bool {
+	if p.kinds[path] != 0 {
+		return true
+	}
+	for _, root := range p.roots {
+		if strings.HasPrefix(path, root+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ZerologPackageOf returns the core package declaring a zerolog type, or nil.
+func (p *Packages) ZerologPackageOf(t types.Type) *types.Package {
+	named, ok := unwrapPointer(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	pkg := named.Obj().Pkg()
+	if p.PackageKind(pkg.Path()) != PkgCore {
+		return nil
+	}
+	return pkg
+}
+
+// isZerologType checks if the type is the named type of a core zerolog package.
+// Handles pointer types transparently.
+func (p *Packages) isZerologType(t types.Type, typeName string) bool {
+	named, ok := unwrapPointer(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Name() == typeName && p.PackageKind(obj.Pkg().Path()) == PkgCore
+}
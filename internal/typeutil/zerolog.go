@@ -26,16 +26,20 @@ package typeutil
 
 import (
 	"go/types"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/ssa"
 )
 
-// Package paths.
+// ZerologPkgPath is the import path of the zerolog package.
+const ZerologPkgPath = zerologPkgPath
+
+// Package paths. Zerolog forks are configured with NewPackages.
 const (
 	zerologPkgPath = "github.com/rs/zerolog"
-	zerologLogPath = "github.com/rs/zerolog/log"
 	contextPkgPath = "context"
+	httpPkgPath    = "net/http"
 )
 
 // Type names.
@@ -43,6 +47,7 @@ const (
 	eventType   = "Event"
 	contextType = "Context"
 	loggerType  = "Logger"
+	hookFunc    = "HookFunc"
 )
 
 // CtxMethod is the method name for setting context.
@@ -53,35 +58,67 @@ const CtxMethod = "Ctx"
 // =============================================================================
 
 // IsEvent checks if the type is *zerolog.Event.
-func IsEvent(t types.Type) bool {
-	return isNamedType(t, zerologPkgPath, eventType)
+func (p *Packages) IsEvent(t types.Type) bool {
+	return p.isZerologType(t, eventType)
 }
 
 // IsContext checks if the type is zerolog.Context.
-func IsContext(t types.Type) bool {
-	return isNamedType(t, zerologPkgPath, contextType)
+func (p *Packages) IsContext(t types.Type) bool {
+	return p.isZerologType(t, contextType)
 }
 
 // IsLogger checks if the type is zerolog.Logger.
-func IsLogger(t types.Type) bool {
-	return isNamedType(t, zerologPkgPath, loggerType)
+func (p *Packages) IsLogger(t types.Type) bool {
+	return p.isZerologType(t, loggerType)
+}
+
+// IsHookFunc checks if the type is zerolog.HookFunc.
+func (p *Packages) IsHookFunc(t types.Type) bool {
+	return p.isZerologType(t, hookFunc)
 }
 
 // =============================================================================
 // Function Checking
 // =============================================================================
 
-// IsCtxFunc returns true for zerolog.Ctx() or log.Ctx() functions.
-func IsCtxFunc(fn *ssa.Function) bool {
-	if fn.Name() != CtxMethod {
+// IsCtxFunc returns true for functions returning the logger of a context:
+// zerolog.Ctx(ctx), log.Ctx(ctx) and hlog.FromRequest(r), in any of the
+// configured packages (see NewPackages).
+func (p *Packages) IsCtxFunc(fn *ssa.Function) bool {
+	pkg := fn.Package()
+	if pkg == nil || pkg.Pkg == nil || fn.Signature.Recv() != nil {
 		return false
 	}
-	pkg := fn.Package()
-	if pkg == nil || pkg.Pkg == nil {
+	switch kind := p.PackageKind(pkg.Pkg.Path()); fn.Name() {
+	case CtxMethod:
+		return kind == PkgCore || kind == PkgLog
+	case "FromRequest":
+		return kind == PkgHlog
+	}
+	return false
+}
+
+// IsZerologFunc returns true for functions and methods declared in the zerolog
+// packages themselves. Their behavior is modeled directly by the tracer, so
+// they are never summarized.
+func (p *Packages) IsZerologFunc(fn *ssa.Function) bool {
+	obj := fn.Object()
+	if obj == nil || obj.Pkg() == nil {
 		return false
 	}
-	path := pkg.Pkg.Path()
-	return path == zerologPkgPath || path == zerologLogPath
+	return p.IsZerologPackage(obj.Pkg().Path())
+}
+
+// IsZerologValue checks if the type is *zerolog.Event, zerolog.Logger or zerolog.Context.
+func (p *Packages) IsZerologValue(t types.Type) bool {
+	return p.IsEvent(t) || p.IsLogger(t) || p.IsContext(t)
+}
+
+// IsGetCtx returns true for Event.GetCtx, which hooks use to read the
+// context of the event they run on.
+func (p *Packages) IsGetCtx(fn *ssa.Function) bool {
+	recv := fn.Signature.Recv()
+	return recv != nil && fn.Name() == "GetCtx" && p.IsEvent(recv.Type())
 }
 
 // =============================================================================
@@ -89,18 +126,18 @@ func IsCtxFunc(fn *ssa.Function) bool {
 // =============================================================================
 
 // ReturnsEvent checks if a function returns *zerolog.Event.
-func ReturnsEvent(fn *ssa.Function) bool {
-	return returnsSingleType(fn, IsEvent)
+func (p *Packages) ReturnsEvent(fn *ssa.Function) bool {
+	return returnsSingleType(fn, p.IsEvent)
 }
 
 // ReturnsLogger checks if a function returns zerolog.Logger.
-func ReturnsLogger(fn *ssa.Function) bool {
-	return returnsSingleType(fn, IsLogger)
+func (p *Packages) ReturnsLogger(fn *ssa.Function) bool {
+	return returnsSingleType(fn, p.IsLogger)
 }
 
 // ReturnsContext checks if a function returns zerolog.Context.
-func ReturnsContext(fn *ssa.Function) bool {
-	return returnsSingleType(fn, IsContext)
+func (p *Packages) ReturnsContext(fn *ssa.Function) bool {
+	return returnsSingleType(fn, p.IsContext)
 }
 
 // returnsSingleType checks if a function returns exactly one value matching the predicate.
@@ -119,8 +156,8 @@ func ReturnsVoid(fn *ssa.Function) bool {
 
 // IsDirectLoggingMethod checks if a function is a direct logging method on Logger
 // that bypasses the Event chain (Print, Printf, Println).
-func IsDirectLoggingMethod(fn *ssa.Function, recv *types.Var) bool {
-	if recv == nil || !IsLogger(recv.Type()) {
+func (p *Packages) IsDirectLoggingMethod(fn *ssa.Function, recv *types.Var) bool {
+	if recv == nil || !p.IsLogger(recv.Type()) {
 		return false
 	}
 	if !ReturnsVoid(fn) {
@@ -131,12 +168,12 @@ func IsDirectLoggingMethod(fn *ssa.Function, recv *types.Var) bool {
 
 // IsDirectLoggingFunc checks if a function is a direct logging function from
 // zerolog/log package that bypasses the Event chain (log.Print, log.Printf).
-func IsDirectLoggingFunc(fn *ssa.Function) bool {
+func (p *Packages) IsDirectLoggingFunc(fn *ssa.Function) bool {
 	pkg := fn.Package()
 	if pkg == nil || pkg.Pkg == nil {
 		return false
 	}
-	if pkg.Pkg.Path() != zerologLogPath {
+	if p.PackageKind(pkg.Pkg.Path()) != PkgLog {
 		return false
 	}
 	if !ReturnsVoid(fn) {
@@ -145,15 +182,262 @@ func IsDirectLoggingFunc(fn *ssa.Function) bool {
 	return strings.HasPrefix(fn.Name(), "Print")
 }
 
+// StdlibLogKind classifies standard library logging functions.
+type StdlibLogKind int
+
+const (
+	// StdlibLogNone is the kind of functions that are not standard library
+	// logging, including the Context variants of log/slog.
+	StdlibLogNone StdlibLogKind = iota
+	StdlibLog                   // log.Printf, (*log.Logger).Println, ...
+	StdlibSlog                  // slog.Info, (*slog.Logger).Warn, ... (not the Context variants)
+)
+
+// Package paths of the standard library loggers.
+const (
+	logPkgPath  = "log"
+	slogPkgPath = "log/slog"
+)
+
+// StdlibLogging classifies fn as a standard library logging function or
+// method, which writes outside zerolog and drops the context:
+//
+//	log:      Print*, Fatal*, Panic* functions and *log.Logger methods
+//	log/slog: Debug, Info, Warn, Error functions and *slog.Logger methods
+func StdlibLogging(fn *types.Func) StdlibLogKind {
+	if fn.Pkg() == nil {
+		return StdlibLogNone
+	}
+	recv := fn.Signature().Recv()
+	switch fn.Pkg().Path() {
+	case logPkgPath:
+		if recv != nil && !isNamedType(recv.Type(), logPkgPath, "Logger") {
+			return StdlibLogNone
+		}
+		for _, prefix := range []string{"Print", "Fatal", "Panic"} {
+			if strings.HasPrefix(fn.Name(), prefix) {
+				return StdlibLog
+			}
+		}
+	case slogPkgPath:
+		if recv != nil && !isNamedType(recv.Type(), slogPkgPath, "Logger") {
+			return StdlibLogNone
+		}
+		switch fn.Name() {
+		case "Debug", "Info", "Warn", "Error":
+			return StdlibSlog
+		}
+	}
+	return StdlibLogNone
+}
+
 // =============================================================================
 // Context Type Checking
 // =============================================================================
 
+// IsRootContextFunc returns true for context.Background() and context.TODO().
+func IsRootContextFunc(fn *ssa.Function) bool {
+	if fn.Package() == nil || fn.Package().Pkg.Path() != contextPkgPath || fn.Signature.Recv() != nil {
+		return false
+	}
+	return fn.Name() == "Background" || fn.Name() == "TODO"
+}
+
+// IsDetachFunc returns true for context.WithoutCancel, which derives a
+// context that is deliberately detached from its parent's cancellation.
+func IsDetachFunc(fn *ssa.Function) bool {
+	return fn.Package() != nil && fn.Package().Pkg.Path() == contextPkgPath &&
+		fn.Signature.Recv() == nil && fn.Name() == "WithoutCancel"
+}
+
+// IsContextDeriveFunc returns true for functions of the context package that
+// derive a new context from the one passed as their first argument
+// (WithValue, WithCancel, WithTimeout, WithoutCancel, ...).
+func IsContextDeriveFunc(fn *ssa.Function) bool {
+	if fn.Package() == nil || fn.Package().Pkg.Path() != contextPkgPath || fn.Signature.Recv() != nil {
+		return false
+	}
+	params := fn.Signature.Params()
+	results := fn.Signature.Results()
+	return params.Len() > 0 && IsContextType(params.At(0).Type()) &&
+		results.Len() > 0 && IsContextType(results.At(0).Type())
+}
+
+// IsHTTPRequest checks if the type is *http.Request, whose Context method
+// provides the request context.
+func IsHTTPRequest(t types.Type) bool {
+	return isPointer(t) && isNamedType(t, httpPkgPath, "Request")
+}
+
+// IsCtxAccessor returns true for methods returning the context of their
+// receiver: (*http.Request).Context and the methods listed in accessors, as
+// "pkgpath.Type.Method" (e.g. cobra's Command.Context).
+func IsCtxAccessor(fn *types.Func, accessors []string) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil || sig.Params().Len() != 0 ||
+		sig.Results().Len() != 1 || !IsContextType(sig.Results().At(0).Type()) {
+		return false
+	}
+	if IsHTTPRequest(sig.Recv().Type()) && fn.Name() == "Context" {
+		return true
+	}
+	return slices.Contains(accessors, FuncName(fn))
+}
+
+// asyncLaunchers are the methods always recognized by IsAsyncLauncher.
+var asyncLaunchers = []string{
+	"golang.org/x/sync/errgroup.Group.Go",
+	"golang.org/x/sync/errgroup.Group.TryGo",
+}
+
+// IsAsyncLauncher returns true for functions running their function
+// arguments in a new goroutine: errgroup.Group.Go, errgroup.Group.TryGo and
+// the functions listed in launchers, as "pkgpath.Func" or
+// "pkgpath.Type.Method".
+func IsAsyncLauncher(fn *types.Func, launchers []string) bool {
+	name := FuncName(fn)
+	return slices.Contains(asyncLaunchers, name) || slices.Contains(launchers, name)
+}
+
+// CtxAccessor returns the name of the context accessor method of t, or ""
+// if it has none. Pointer methods are included, since parameters are
+// addressable.
+func CtxAccessor(t types.Type, accessors []string) string {
+	mset := types.NewMethodSet(t)
+	if _, ok := t.Underlying().(*types.Interface); !ok && !isPointer(t) {
+		mset = types.NewMethodSet(types.NewPointer(t))
+	}
+	for sel := range mset.Methods() {
+		if fn, ok := sel.Obj().(*types.Func); ok && IsCtxAccessor(fn, accessors) {
+			return fn.Name()
+		}
+	}
+	return ""
+}
+
 // IsContextType checks if the type is context.Context.
 func IsContextType(t types.Type) bool {
 	return isNamedType(t, contextPkgPath, "Context")
 }
 
+// EmbedsContext checks if the type is context.Context, an interface (or type
+// parameter constraint) including the context.Context methods, or a struct
+// embedding a context.Context field:
+//
+//	type RequestCtx interface { context.Context; UserID() string }
+//	func f[C context.Context](c C)
+//	type Job struct { context.Context; ID int }
+func EmbedsContext(t types.Type) bool {
+	if IsContextType(t) {
+		return true
+	}
+	switch u := unwrapPointer(t).Underlying().(type) {
+	case *types.Interface:
+		return ImplementsContext(t)
+	case *types.Struct:
+		for field := range u.Fields() {
+			if field.Embedded() && EmbedsContext(field.Type()) {
+				return ImplementsContext(t)
+			}
+		}
+	}
+	return false
+}
+
+// ImplementsContext checks if values of the type can be passed as a
+// context.Context, i.e. its method set has Deadline, Done, Err and Value.
+//
+// The methods are matched by name and arity, which avoids looking up the
+// context package from the type's package.
+func ImplementsContext(t types.Type) bool {
+	if IsContextType(t) {
+		return true
+	}
+	mset := types.NewMethodSet(t)
+	for name, arity := range contextMethods {
+		sel := mset.Lookup(nil, name)
+		if sel == nil {
+			return false
+		}
+		sig, ok := sel.Type().(*types.Signature)
+		if !ok || sig.Params().Len() != arity[0] || sig.Results().Len() != arity[1] {
+			return false
+		}
+	}
+	return true
+}
+
+// CtxField returns the name of a context.Context field of a struct or
+// pointer to struct, or "" if it has none. Only fields accessible from pkg
+// are considered.
+//
+//	type handler struct { ctx context.Context }   →  "ctx"
+//	type Params struct { Ctx context.Context }    →  "Ctx"
+func CtxField(t types.Type, pkg *types.Package) string {
+	st, ok := unwrapPointer(t).Underlying().(*types.Struct)
+	if !ok {
+		return ""
+	}
+	for field := range st.Fields() {
+		if field.Embedded() || !IsContextType(field.Type()) {
+			continue
+		}
+		if field.Exported() || field.Pkg() == pkg {
+			return field.Name()
+		}
+	}
+	return ""
+}
+
+// FieldName returns the name of the i-th field of a struct or pointer to
+// struct type.
+func FieldName(t types.Type, i int) string {
+	st, ok := unwrapPointer(t).Underlying().(*types.Struct)
+	if !ok || i >= st.NumFields() {
+		return ""
+	}
+	return st.Field(i).Name()
+}
+
+// contextMethods maps the context.Context methods to their parameter and
+// result counts.
+var contextMethods = map[string][2]int{
+	"Deadline": {0, 2},
+	"Done":     {0, 1},
+	"Err":      {0, 1},
+	"Value":    {1, 1},
+}
+
+// FuncName returns the qualified name of a function or method, as used in
+// configuration lists: "pkgpath.Func" or "pkgpath.Type.Method".
+func FuncName(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return fn.Name()
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if ok && sig.Recv() != nil {
+		if named, ok := unwrapPointer(sig.Recv().Type()).(*types.Named); ok {
+			return fn.Pkg().Path() + "." + named.Obj().Name() + "." + fn.Name()
+		}
+	}
+	return fn.Pkg().Path() + "." + fn.Name()
+}
+
+// ShortFuncName returns the name of fn for messages, qualified by its
+// package name: "log.Printf" or "slog.Logger.Info".
+func ShortFuncName(fn *types.Func) string {
+	name := fn.Name()
+	if recv := fn.Signature().Recv(); recv != nil {
+		if named, ok := unwrapPointer(recv.Type()).(*types.Named); ok {
+			name = named.Obj().Name() + "." + name
+		}
+	}
+	if fn.Pkg() == nil {
+		return name
+	}
+	return fn.Pkg().Name() + "." + name
+}
+
 // =============================================================================
 // Type Utilities
 // =============================================================================
@@ -166,6 +450,12 @@ func unwrapPointer(t types.Type) types.Type {
 	return t
 }
 
+// isPointer returns true if t is a pointer type.
+func isPointer(t types.Type) bool {
+	_, ok := t.(*types.Pointer)
+	return ok
+}
+
 // isNamedType checks if the type matches the given package path and type name.
 // Handles pointer types transparently.
 //
@@ -0,0 +1,79 @@
+// Package accessors tests -ctx-accessors, listing methods that return the
+// context of their receiver.
+package accessors
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Command is shaped like cobra.Command. Listed: accessors.Command.Context
+type Command struct{ ctx context.Context }
+
+func (c *Command) Context() context.Context { return c.ctx }
+
+// Stream is shaped like grpc.ServerStream. Listed: accessors.Stream.Context
+type Stream interface {
+	Context() context.Context
+	SendMsg(m any) error
+}
+
+// Job has its own accessor. Listed: accessors.Job.Ctx
+type Job struct {
+	ctx    context.Context
+	logger zerolog.Logger
+}
+
+func (j *Job) Ctx() context.Context { return j.ctx }
+
+// Unlisted has a context method that is not configured.
+type Unlisted struct{ ctx context.Context }
+
+func (u *Unlisted) Context() context.Context { return u.ctx }
+
+// ===== SHOULD REPORT =====
+
+func badCommand(cmd *Command, args []string) {
+	log.Info().Strs("args", args).Msg("run") // want `zerolog call chain missing .Ctx\(cmd.Context\(\)\)`
+}
+
+func badStream(srv any, stream Stream) error {
+	log.Info().Msg("stream") // want `zerolog call chain missing .Ctx\(stream.Context\(\)\)`
+	return nil
+}
+
+func (j *Job) badRun() {
+	j.logger.Info().Msg("run") // want `zerolog call chain missing .Ctx\(j.Ctx\(\)\)`
+}
+
+func badJobValue(job Job) {
+	job.logger.Info().Msg("run") // want `zerolog call chain missing .Ctx\(job.Ctx\(\)\)`
+}
+
+func badStreamStale(stream Stream) {
+	tctx, cancel := context.WithTimeout(stream.Context(), time.Second)
+	defer cancel()
+	_ = tctx
+	log.Info().Ctx(stream.Context()).Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context tctx`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodCommand(cmd *Command, args []string) {
+	log.Info().Ctx(cmd.Context()).Msg("run") // OK
+}
+
+func (j *Job) goodRun() {
+	j.logger.Info().Ctx(j.Ctx()).Msg("run") // OK
+}
+
+func badPrefersCtxParam(ctx context.Context, cmd *Command) {
+	log.Info().Msg("run") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func goodUnlisted(u *Unlisted) {
+	log.Info().Msg("unlisted") // OK - not configured
+}
@@ -0,0 +1,63 @@
+// Package applog contains logging helpers used from another package.
+// Their return summaries are exported as facts (see handler).
+package applog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func NewEvent(ctx context.Context, l zerolog.Logger) *zerolog.Event { // want NewEvent:"returns\\(with-ctx\\)"
+	return l.Info().Ctx(ctx).Str("from", "applog")
+}
+
+func WithFields(e *zerolog.Event) *zerolog.Event { // want WithFields:"returns\\(from-params\\[0\\]\\)"
+	return e.Str("from", "applog")
+}
+
+func Derive(l zerolog.Logger) zerolog.Logger { // want Derive:"returns\\(from-params\\[0\\]\\)"
+	return l.With().Str("from", "applog").Logger()
+}
+
+func Global() *zerolog.Event { // want Global:"returns\\(without-ctx\\)"
+	return log.Info()
+}
+
+// unexported helpers are never called from other packages: no fact
+func newEvent(ctx context.Context, l zerolog.Logger) *zerolog.Event {
+	return l.Info().Ctx(ctx)
+}
+
+func Use(ctx context.Context, l zerolog.Logger) {
+	newEvent(ctx, l).Msg("ok") // OK
+}
+
+func Finish(e *zerolog.Event) { // want Finish:"consumes\\(0\\)"
+	e.Msg("finished")
+}
+
+type loggerKey struct{}
+
+// FromContext returns the logger stored in ctx by this package. The fallback
+// path hides the context from the summary, so it is declared a provider.
+//
+//zerologlintctx:provider
+func FromContext(ctx context.Context) zerolog.Logger { // want FromContext:"provider"
+	if l, ok := ctx.Value(loggerKey{}).(zerolog.Logger); ok {
+		return l
+	}
+	return log.Logger
+}
+
+// fromContext is a provider used within this package only: no fact
+//
+//zerologlintctx:provider
+func fromContext(ctx context.Context) zerolog.Logger {
+	return FromContext(ctx)
+}
+
+func UseProvider(ctx context.Context) {
+	fromContext(ctx).Info().Msg("ok") // OK
+}
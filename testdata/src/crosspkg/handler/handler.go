@@ -0,0 +1,61 @@
+// Package handler calls helpers from applog, tracked via exported facts.
+package handler
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"crosspkg/applog"
+)
+
+// ===== SHOULD REPORT =====
+
+func badGlobal(ctx context.Context) {
+	applog.Global().Msg("global") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badWithFields(ctx context.Context, l zerolog.Logger) {
+	applog.WithFields(l.Info()).Msg("fields") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badDerive(ctx context.Context, l zerolog.Logger) {
+	applog.Derive(l).Info().Msg("derived") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodProvider(ctx context.Context) {
+	applog.FromContext(ctx).Info().Msg("ok") // OK - declared provider
+}
+
+func goodProviderDerived(ctx context.Context) {
+	l := applog.FromContext(ctx).With().Str("k", "v").Logger()
+	l.Info().Msg("ok") // OK
+}
+
+func goodNewEvent(ctx context.Context, l zerolog.Logger) {
+	applog.NewEvent(ctx, l).Msg("ok") // OK
+}
+
+func goodWithFields(ctx context.Context, l zerolog.Logger) {
+	applog.WithFields(l.Info().Ctx(ctx)).Msg("ok") // OK
+}
+
+func goodDerive(ctx context.Context, l zerolog.Logger) {
+	applog.Derive(l.With().Ctx(ctx).Logger()).Info().Msg("ok") // OK
+}
+
+func goodNestedHelpers(ctx context.Context, l zerolog.Logger) {
+	applog.WithFields(applog.NewEvent(ctx, l)).Msg("ok") // OK
+}
+
+// ===== CONSUMERS =====
+
+func badFinish(ctx context.Context, l zerolog.Logger) {
+	applog.Finish(l.Info()) // want `zerolog call chain missing .Ctx\(ctx\) before passing to Finish`
+}
+
+func goodFinish(ctx context.Context, l zerolog.Logger) {
+	applog.Finish(l.Info().Ctx(ctx)) // OK
+}
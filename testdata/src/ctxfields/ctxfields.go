@@ -0,0 +1,76 @@
+// Package ctxfields tests -ctx-fields, where context.Context fields of the
+// receiver or of struct parameters are a context source.
+package ctxfields
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type loggerHolder struct {
+	ctx    context.Context
+	logger zerolog.Logger
+}
+
+// Params is an fx-style parameter struct.
+type Params struct {
+	Ctx    context.Context
+	Logger zerolog.Logger
+}
+
+// noCtx has no context field.
+type noCtx struct {
+	logger zerolog.Logger
+}
+
+// ===== SHOULD REPORT =====
+
+func (h *loggerHolder) logBad() {
+	h.logger.Info().Msg("struct method") // want `zerolog call chain missing .Ctx\(h.ctx\)`
+}
+
+func (h loggerHolder) logBadValue() {
+	h.logger.Info().Msg("value receiver") // want `zerolog call chain missing .Ctx\(h.ctx\)`
+}
+
+func logBadParams(p Params) {
+	p.Logger.Info().Msg("params") // want `zerolog call chain missing .Ctx\(p.Ctx\)`
+}
+
+func (h *loggerHolder) logBadClosure() {
+	go func() {
+		h.logger.Info().Msg("async") // want `zerolog call chain missing .Ctx\(h.ctx\)`
+	}()
+}
+
+func (h *loggerHolder) logBadStale() {
+	tctx, cancel := context.WithTimeout(h.ctx, time.Second)
+	defer cancel()
+	_ = tctx
+	h.logger.Info().Ctx(h.ctx).Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context tctx`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func (h *loggerHolder) logGood() {
+	h.logger.Info().Ctx(h.ctx).Msg("struct method with ctx") // OK
+}
+
+func logGoodParams(p Params) {
+	p.Logger.Info().Ctx(p.Ctx).Msg("params") // OK
+}
+
+func (h *loggerHolder) logPrefersParam(ctx context.Context) {
+	h.logger.Info().Msg("param first") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func (n *noCtx) logNoField() {
+	n.logger.Info().Msg("no field") // OK - nothing to pass
+}
+
+func callsLogBad(ctx context.Context, logger zerolog.Logger) {
+	h := &loggerHolder{ctx: ctx, logger: logger}
+	h.logBad() // OK - reported inside logBad instead
+}
@@ -0,0 +1,11 @@
+// Package applog holds the application logger used by the ctxlogger fixtures.
+package applog
+
+import (
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// Logger is the application logger.
+var Logger = zerolog.New(os.Stdout)
@@ -0,0 +1,70 @@
+// Package ctxlogger tests -prefer-ctx-logger, where chains in functions with
+// a context must use the logger of the context. See ctxlogger.go.golden for
+// the suggested fixes.
+package ctxlogger
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+var appLogger = zerolog.New(os.Stdout)
+
+// ===== SHOULD REPORT =====
+
+// No fix: os.Stdout would be dropped.
+func badNew(ctx context.Context) {
+	zerolog.New(os.Stdout).Info().Ctx(ctx).Msg("x") // want `zerolog chain rooted at zerolog.New instead of the context logger; use log.Ctx\(ctx\)`
+}
+
+func badNop(ctx context.Context) {
+	zerolog.Nop().With().Str("k", "v").Logger().Warn().Ctx(ctx).Msg("x") // want `zerolog chain rooted at zerolog.Nop instead of the context logger`
+}
+
+func badGlobal(ctx context.Context) {
+	appLogger.Info().Ctx(ctx).Msg("x") // want `zerolog chain rooted at ctxlogger.appLogger instead of the context logger`
+}
+
+func badGlobalDerived(ctx context.Context) {
+	l := appLogger.With().Str("k", "v").Logger()
+	l.Info().Ctx(ctx).Msg("x") // want `zerolog chain rooted at ctxlogger.appLogger instead of the context logger`
+}
+
+func badGlobalBranch(ctx context.Context, verbose bool) {
+	l := appLogger
+	if verbose {
+		l = zerolog.New(os.Stderr)
+	}
+	l.Debug().Ctx(ctx).Msg("x") // want `zerolog chain rooted at ctxlogger.appLogger instead of the context logger`
+}
+
+// No fix: the local variable would be left unused.
+func badLocal(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.Info().Ctx(ctx).Msg("x") // want `zerolog chain rooted at zerolog.New instead of the context logger`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodCtxLogger(ctx context.Context) {
+	zerolog.Ctx(ctx).Info().Msg("x")
+	zerolog.Ctx(ctx).With().Str("k", "v").Logger().Info().Msg("x")
+}
+
+func goodParamLogger(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Msg("x")
+}
+
+func goodSomePaths(ctx context.Context, verbose bool) {
+	l := appLogger
+	if verbose {
+		l = *zerolog.Ctx(ctx)
+	}
+	l.Info().Ctx(ctx).Msg("x")
+}
+
+func goodNoCtx() {
+	appLogger.Info().Msg("x")
+}
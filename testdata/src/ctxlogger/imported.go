@@ -0,0 +1,15 @@
+package ctxlogger
+
+import (
+	"context"
+
+	"ctxlogger/applog"
+)
+
+func badImportedGlobal(ctx context.Context) {
+	applog.Logger.Info().Ctx(ctx).Msg("x") // want `zerolog chain rooted at applog.Logger instead of the context logger`
+}
+
+func goodImportedNoCtx() {
+	applog.Logger.Info().Msg("x")
+}
@@ -0,0 +1,12 @@
+package ctxlogger
+
+import (
+	"context"
+
+	"ctxlogger/applog"
+)
+
+// No fix: applog would be left unused.
+func badLastImportUse(ctx context.Context) {
+	applog.Logger.Info().Ctx(ctx).Msg("x") // want `zerolog chain rooted at applog.Logger instead of the context logger`
+}
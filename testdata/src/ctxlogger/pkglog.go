@@ -0,0 +1,32 @@
+package ctxlogger
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ===== SHOULD REPORT =====
+
+func badLogFunc(ctx context.Context) {
+	log.Info().Ctx(ctx).Msg("x") // want `zerolog chain rooted at log.Info instead of the context logger; use log.Ctx\(ctx\)`
+}
+
+// Reported once: the chain is not also missing .Ctx(ctx).
+func badLogFuncNoCtx(ctx context.Context) {
+	log.Info().Msg("x") // want `zerolog chain rooted at log.Info instead of the context logger; use log.Ctx\(ctx\)`
+}
+
+func badLogGlobal(ctx context.Context) {
+	log.Logger.Warn().Ctx(ctx).Msg("x") // want `zerolog chain rooted at log.Logger instead of the context logger`
+}
+
+func badLogWith(ctx context.Context) {
+	log.With().Str("k", "v").Logger().Error().Ctx(ctx).Msg("x") // want `zerolog chain rooted at log.With instead of the context logger`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodLogCtx(ctx context.Context) {
+	log.Ctx(ctx).Info().Msg("x")
+}
@@ -0,0 +1,15 @@
+package ctxlogger
+
+import (
+	"context"
+	"log"
+
+	"github.com/rs/zerolog"
+)
+
+var stdLogger = log.Default()
+
+// No fix: log is the standard library package here.
+func badShadowedLog(ctx context.Context) {
+	zerolog.New(nil).Info().Ctx(ctx).Msg("x") // want `zerolog chain rooted at zerolog.New instead of the context logger`
+}
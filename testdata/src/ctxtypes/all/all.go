@@ -0,0 +1,40 @@
+// Package all tests -ctx-types=all, where any parameter type implementing
+// context.Context is a context source.
+package all
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// FrameworkCtx implements context.Context without embedding it, like *gin.Context.
+type FrameworkCtx struct{ parent context.Context }
+
+func (f *FrameworkCtx) Deadline() (time.Time, bool) { return f.parent.Deadline() }
+func (f *FrameworkCtx) Done() <-chan struct{}       { return f.parent.Done() }
+func (f *FrameworkCtx) Err() error                  { return f.parent.Err() }
+func (f *FrameworkCtx) Value(key any) any           { return f.parent.Value(key) }
+
+// notCtx has some of the methods only.
+type notCtx struct{}
+
+func (notCtx) Done() <-chan struct{} { return nil }
+func (notCtx) Err() error            { return nil }
+
+func badFrameworkCtx(c *FrameworkCtx, logger zerolog.Logger) {
+	logger.Info().Msg("framework") // want `zerolog call chain missing .Ctx\(c\)`
+}
+
+func goodFrameworkCtx(c *FrameworkCtx, logger zerolog.Logger) {
+	logger.Info().Ctx(c).Msg("framework") // OK
+}
+
+func goodFrameworkCtxValue(c FrameworkCtx, logger zerolog.Logger) {
+	logger.Info().Msg("value") // OK - methods are on the pointer
+}
+
+func goodNotCtx(n notCtx, logger zerolog.Logger) {
+	logger.Info().Msg("not a context") // OK
+}
@@ -0,0 +1,26 @@
+// Package exact tests -ctx-types=exact, where only context.Context
+// parameters are context sources.
+package exact
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type RequestCtx interface {
+	context.Context
+	UserID() string
+}
+
+func badExactCtx(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Msg("exact") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func goodCustomCtxIgnored(rc RequestCtx, logger zerolog.Logger) {
+	logger.Info().Msg("custom") // OK - not checked in exact mode
+}
+
+func goodGenericCtxIgnored[C context.Context](c C, logger zerolog.Logger) {
+	logger.Info().Msg("generic") // OK - not checked in exact mode
+}
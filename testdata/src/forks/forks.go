@@ -0,0 +1,36 @@
+// Package forks tests -zerolog-pkgs and -zerolog-log-pkgs, recognizing
+// zerolog forks and packages wrapping a global logger.
+package forks
+
+import (
+	"context"
+
+	"github.com/ourorg/zerolog"
+	"github.com/ourorg/zerolog/log"
+
+	"forks/logging"
+)
+
+func badFork(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Msg("fork") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badForkLog(ctx context.Context) {
+	log.Info().Msg("fork log") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badLogging(ctx context.Context) {
+	logging.Print("wrapped") // want `zerolog direct logging bypasses context; use Event chain with .Ctx\(ctx\)`
+}
+
+func goodFork(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Msg("fork") // OK
+}
+
+func goodForkCtx(ctx context.Context) {
+	zerolog.Ctx(ctx).Info().Msg("fork") // OK
+}
+
+func goodLoggingCtx(ctx context.Context) {
+	logging.Ctx(ctx).Info().Msg("wrapped") // OK
+}
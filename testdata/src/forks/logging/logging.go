@@ -0,0 +1,14 @@
+// Package logging wraps the global zerolog logger, like zerolog/log.
+package logging
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+var logger zerolog.Logger
+
+func Ctx(ctx context.Context) *zerolog.Logger { return zerolog.Ctx(ctx) }
+func Info() *zerolog.Event                    { return logger.Info() }
+func Print(v ...any)                          { logger.Print(v...) }
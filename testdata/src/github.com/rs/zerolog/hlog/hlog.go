@@ -0,0 +1,13 @@
+// Stub package for testing - net/http helpers
+package hlog
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog"
+)
+
+// FromRequest gets the logger in the request's context.
+func FromRequest(r *http.Request) *zerolog.Logger {
+	return zerolog.Ctx(r.Context())
+}
@@ -26,6 +26,7 @@ func Log() *zerolog.Event                          { return Logger.Log() }
 func Err(err error) *zerolog.Event                 { return Logger.Err(err) }
 func WithLevel(level zerolog.Level) *zerolog.Event { return Logger.WithLevel(level) }
 func With() zerolog.Context                        { return Logger.With() }
+func Hook(h zerolog.Hook) zerolog.Logger           { return Logger.Hook(h) }
 
 // Direct logging functions (bypass Event chain)
 func Print(v ...any)                 { Logger.Print(v...) }
@@ -64,9 +64,12 @@ func (l Logger) WithLevel(level Level) *Event { return &Event{} }
 func (l Logger) With() Context                { return Context{} }
 func (l Logger) Level(lvl Level) Logger       { return l }
 func (l Logger) Sample(s Sampler) Logger      { return l }
-func (l Logger) Hook(h Hook) Logger           { return l }
+func (l Logger) Hook(hooks ...Hook) Logger    { return l }
 func (l Logger) Output(w io.Writer) Logger    { return l }
 
+// WithContext returns a copy of ctx with the receiver attached.
+func (l Logger) WithContext(ctx context.Context) context.Context { return ctx }
+
 // Direct logging methods (bypass Event chain)
 func (l *Logger) Print(v ...any)                 {}
 func (l *Logger) Printf(format string, v ...any) {}
@@ -89,6 +92,12 @@ type Hook interface {
 	Run(e *Event, level Level, msg string)
 }
 
+// HookFunc is an adaptor to allow the use of an ordinary function as a Hook.
+type HookFunc func(e *Event, level Level, message string)
+
+// Run implements the Hook interface.
+func (h HookFunc) Run(e *Event, level Level, message string) { h(e, level, message) }
+
 // Context methods (for building loggers with preset fields)
 func (c Context) Str(key, val string) Context               { return c }
 func (c Context) Strs(key string, val []string) Context     { return c }
@@ -115,6 +124,7 @@ func (c Context) Logger() Logger                            { return Logger{} }
 
 // Event methods
 func (e *Event) Ctx(ctx context.Context) *Event                   { return e }
+func (e *Event) GetCtx() context.Context                          { return nil }
 func (e *Event) Str(key, val string) *Event                       { return e }
 func (e *Event) Strs(key string, val []string) *Event             { return e }
 func (e *Event) Int(key string, val int) *Event                   { return e }
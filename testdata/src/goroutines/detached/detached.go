@@ -0,0 +1,113 @@
+// Package detached contains test fixtures for -goroutine-ctx=detached.
+package detached
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+
+	"goroutines/pool"
+)
+
+// ===== SHOULD REPORT =====
+
+func badCapturedCtx(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		logger.Info().Ctx(ctx).Msg("async") // want `zerolog .Ctx\(\) in a goroutine is given a context of the launching function, canceled when it returns; use context.WithoutCancel or derive one in the goroutine \(-goroutine-ctx=detached\)`
+	}()
+}
+
+func badCapturedCtxLogger(ctx context.Context) {
+	go func() {
+		log.Ctx(ctx).Info().Msg("async") // want `zerolog .Ctx\(\) in a goroutine is given a context of the launching function`
+	}()
+}
+
+func badNestedClosure(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		func() {
+			logger.Info().Ctx(ctx).Msg("async") // want `zerolog .Ctx\(\) in a goroutine is given a context of the launching function`
+		}()
+	}()
+}
+
+func badRequestCtx(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		log.Info().Ctx(r.Context()).Msg("async") // want `zerolog .Ctx\(\) in a goroutine is given a context of the launching function`
+	}()
+}
+
+func badErrgroup(ctx context.Context, logger zerolog.Logger) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		logger.Info().Ctx(gctx).Msg("async") // want `zerolog .Ctx\(\) in a goroutine is given a context of the launching function`
+		return nil
+	})
+	return g.Wait()
+}
+
+func badConfiguredLauncher(ctx context.Context, logger zerolog.Logger, p *pool.Pool) {
+	p.Submit(func() {
+		logger.Info().Ctx(ctx).Msg("async") // want `zerolog .Ctx\(\) in a goroutine is given a context of the launching function`
+	})
+}
+
+func badMissingCtx(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		logger.Info().Msg("async") // want `zerolog call chain missing .Ctx\(ctx\); in a goroutine, use context.WithoutCancel\(ctx\) or derive one \(-goroutine-ctx=detached\)`
+	}()
+}
+
+func badMissingCtxErrgroup(ctx context.Context, logger zerolog.Logger) error {
+	g, gctx := errgroup.WithContext(ctx)
+	defer logger.Info().Ctx(gctx).Msg("done")
+	g.Go(func() error {
+		logger.Info().Msg("async") // want `zerolog call chain missing .Ctx\(gctx\); in a goroutine, use context.WithoutCancel\(gctx\)`
+		return nil
+	})
+	return g.Wait()
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodWithoutCancelInGoroutine(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		logger.Info().Ctx(context.WithoutCancel(ctx)).Msg("async")
+	}()
+}
+
+func goodWithoutCancelCaptured(ctx context.Context, logger zerolog.Logger) {
+	dctx := context.WithoutCancel(ctx)
+	go func() {
+		logger.Info().Ctx(dctx).Msg("async")
+	}()
+}
+
+func goodDerivedInGoroutine(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		tctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+		defer cancel()
+		logger.Info().Ctx(tctx).Msg("async")
+	}()
+}
+
+func goodRootCtxCaptured(logger zerolog.Logger) {
+	ctx := context.Background()
+	go func() {
+		logger.Info().Ctx(ctx).Msg("async")
+	}()
+}
+
+func goodSynchronousClosure(ctx context.Context, logger zerolog.Logger) {
+	func() {
+		logger.Info().Ctx(ctx).Msg("sync")
+	}()
+}
+
+func goodOutsideGoroutine(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Msg("sync")
+}
@@ -0,0 +1,64 @@
+// Package exempt contains test fixtures for -goroutine-ctx=exempt.
+package exempt
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"goroutines/pool"
+)
+
+// ===== SHOULD REPORT =====
+
+func badOutsideGoroutine(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Msg("sync") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badSynchronousClosure(ctx context.Context, logger zerolog.Logger) {
+	func() {
+		logger.Info().Msg("sync") // want `zerolog call chain missing .Ctx\(ctx\)`
+	}()
+}
+
+func badCtxCreatedInGoroutine(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		tctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+		defer cancel()
+		logger.Info().Msg("async") // want `zerolog call chain missing .Ctx\(tctx\)`
+		_ = tctx
+	}()
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodGoroutine(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		logger.Info().Msg("async")
+	}()
+}
+
+func goodNestedInGoroutine(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		func() {
+			logger.Info().Msg("async")
+		}()
+	}()
+}
+
+func goodErrgroup(ctx context.Context, logger zerolog.Logger) error {
+	g, _ := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		logger.Info().Msg("async")
+		return nil
+	})
+	return g.Wait()
+}
+
+func goodConfiguredLauncher(ctx context.Context, logger zerolog.Logger, p *pool.Pool) {
+	p.Submit(func() {
+		logger.Info().Msg("async")
+	})
+}
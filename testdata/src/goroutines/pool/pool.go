@@ -0,0 +1,7 @@
+// Package pool is an async launcher configured with -async-launchers.
+package pool
+
+type Pool struct{}
+
+// Submit runs f in a new goroutine.
+func (p *Pool) Submit(f func()) { go f() }
@@ -0,0 +1,126 @@
+// Package hooks tests -hook-aware, where a context is only required on
+// chains whose logger has a hook calling Event.GetCtx attached.
+package hooks
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"hooks/tracing"
+)
+
+type spanHook struct{}
+
+func (spanHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) { // want Run:"reads-ctx"
+	if ctx := e.GetCtx(); ctx != nil {
+		e.Str("span", "x")
+	}
+}
+
+type auditHook struct{ prefix string }
+
+func (h *auditHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	e.Str("audit", h.prefix)
+}
+
+var hooked = zerolog.New(os.Stdout).Hook(spanHook{})
+
+var plain = zerolog.New(os.Stdout).Hook(&auditHook{prefix: "x"})
+
+func newHooked() zerolog.Logger {
+	return zerolog.New(os.Stdout).Hook(&auditHook{}, spanHook{})
+}
+
+func init() {
+	log.Logger = log.Hook(spanHook{})
+}
+
+// ===== SHOULD REPORT =====
+
+func badHookedGlobal(ctx context.Context) {
+	hooked.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badHookedLocal(ctx context.Context) {
+	logger := zerolog.New(os.Stdout).Hook(spanHook{})
+	logger.Info().Str("k", "v").Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badHookedDerived(ctx context.Context) {
+	logger := hooked.With().Str("k", "v").Logger()
+	logger.Warn().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badHookedVariadic(ctx context.Context) {
+	newHooked().Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badHookFunc(ctx context.Context) {
+	logger := zerolog.New(os.Stdout).Hook(zerolog.HookFunc(func(e *zerolog.Event, _ zerolog.Level, _ string) {
+		_ = e.GetCtx()
+	}))
+	logger.Error().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badGlobalLog(ctx context.Context) {
+	log.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+	log.Print("x")      // want `zerolog direct logging bypasses context; use Event chain with .Ctx\(ctx\)`
+}
+
+func badBranch(ctx context.Context, verbose bool) {
+	logger := plain
+	if verbose {
+		logger = hooked
+	}
+	logger.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badCrossPkgGlobal(ctx context.Context) {
+	tracing.Logger.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badCrossPkgFunc(ctx context.Context) {
+	tracing.NewLogger().Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badCrossPkgHook(ctx context.Context) {
+	logger := zerolog.New(os.Stdout).Hook(tracing.TraceHook{})
+	logger.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// Loggers of unknown origin may have a hook attached by the caller
+
+func badUnknownParam(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+type service struct{ logger zerolog.Logger }
+
+func (s *service) badUnknownField(ctx context.Context) {
+	s.logger.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodHookedWithCtx(ctx context.Context) {
+	hooked.Info().Ctx(ctx).Msg("x")
+	log.Info().Ctx(ctx).Msg("x")
+}
+
+func goodNoHook(ctx context.Context) {
+	zerolog.New(os.Stdout).Info().Msg("x")
+}
+
+func goodPlainHook(ctx context.Context) {
+	plain.Info().Msg("x")
+	plain.Print("x")
+}
+
+func goodCrossPkgPlain(ctx context.Context) {
+	tracing.Plain.Info().Msg("x")
+	tracing.NewPlainLogger().Info().Msg("x")
+	zerolog.New(os.Stdout).Hook(tracing.LevelHook{}).Info().Msg("x")
+}
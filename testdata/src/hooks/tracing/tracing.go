@@ -0,0 +1,51 @@
+// Package tracing is a hook library: its facts carry the context-reading
+// hook and the loggers it is attached to into the hooks package.
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// TraceHook adds the trace ID of the event's context.
+type TraceHook struct{}
+
+func (TraceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) { // want Run:"reads-ctx"
+	e.Str("trace_id", traceID(e.GetCtx()))
+}
+
+func traceID(ctx context.Context) string {
+	if id, ok := ctx.Value("trace_id").(string); ok {
+		return id
+	}
+	return ""
+}
+
+// LevelHook reads nothing from the context.
+type LevelHook struct{}
+
+func (LevelHook) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	e.Str("level_name", "x")
+}
+
+// Logger has TraceHook attached.
+var Logger = zerolog.New(os.Stdout).Hook(TraceHook{}) // want Logger:"hooked"
+
+// Plain has no hook reading the context.
+var Plain = zerolog.New(os.Stdout).Hook(LevelHook{})
+
+func NewLogger() zerolog.Logger { // want NewLogger:"hooked" NewLogger:"returns\\(without-ctx\\)"
+	return zerolog.New(os.Stdout).Hook(TraceHook{})
+}
+
+func NewPlainLogger() zerolog.Logger { // want NewPlainLogger:"returns\\(without-ctx\\)"
+	return zerolog.New(os.Stdout)
+}
+
+// Trace logs through log.Logger, which importers may hook.
+func Trace(ctx context.Context) {
+	log.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
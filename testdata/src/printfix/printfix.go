@@ -0,0 +1,38 @@
+// Package printfix contains test fixtures for the suggested fix rewriting
+// direct logging calls into Event chains. See printfix.go.golden.
+package printfix
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func fixPrint(ctx context.Context, logger zerolog.Logger) {
+	logger.Print("a", 1) // want `zerolog direct logging bypasses context`
+}
+
+func fixPrintf(ctx context.Context, logger zerolog.Logger) {
+	logger.Printf("value: %d", 1) // want `zerolog direct logging bypasses context`
+}
+
+func fixPrintln(ctx context.Context, logger zerolog.Logger) {
+	logger.Println("a", "b") // want `zerolog direct logging bypasses context`
+}
+
+func fixPrintVariadic(ctx context.Context, logger zerolog.Logger, args []any) {
+	logger.Print(args...) // want `zerolog direct logging bypasses context`
+}
+
+func fixPrintfVariadic(ctx context.Context, logger *zerolog.Logger, args []any) {
+	logger.Printf("values: %v %v", args...) // want `zerolog direct logging bypasses context`
+}
+
+func fixGlobalPrint(ctx context.Context) {
+	log.Print("global") // want `zerolog direct logging bypasses context`
+}
+
+func fixGlobalPrintf(ctx context.Context) {
+	log.Printf("global %s", "printf") // want `zerolog direct logging bypasses context`
+}
@@ -0,0 +1,14 @@
+package printfix
+
+import (
+	"context"
+	f "fmt"
+
+	"github.com/rs/zerolog"
+)
+
+var _ = f.Sprint
+
+func fixPrintRenamedFmt(ctx context.Context, logger zerolog.Logger) {
+	logger.Print("renamed fmt") // want `zerolog direct logging bypasses context`
+}
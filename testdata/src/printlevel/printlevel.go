@@ -0,0 +1,17 @@
+// Package printlevel tests the -print-level flag. See printlevel.go.golden.
+package printlevel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+func fixPrintAtInfo(ctx context.Context, logger zerolog.Logger) {
+	logger.Print("info") // want `zerolog direct logging bypasses context`
+}
+
+func fixPrintfAtInfo(ctx context.Context, logger zerolog.Logger) {
+	logger.Printf("%s", fmt.Sprint("info")) // want `zerolog direct logging bypasses context`
+}
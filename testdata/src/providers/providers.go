@@ -0,0 +1,45 @@
+// Package providers tests -providers, listing functions whose returned
+// Logger or Event carries a context.
+package providers
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Event is listed: providers.Event
+func Event(ctx context.Context, level zerolog.Level) *zerolog.Event { // want Event:"returns\\(without-ctx\\)"
+	return loggers[ctx].WithLevel(level)
+}
+
+// Registry is listed: providers.Registry.Logger
+type Registry struct{}
+
+func (Registry) Logger(ctx context.Context) zerolog.Logger { // want Logger:"returns\\(without-ctx\\)"
+	return loggers[ctx]
+}
+
+// unlisted is not a provider.
+func unlisted(ctx context.Context) zerolog.Logger {
+	return loggers[ctx]
+}
+
+var loggers = map[context.Context]zerolog.Logger{}
+
+func goodEvent(ctx context.Context) {
+	Event(ctx, zerolog.InfoLevel).Msg("ok") // OK
+}
+
+func goodRegistry(ctx context.Context, r Registry) {
+	r.Logger(ctx).Info().Str("k", "v").Msg("ok") // OK
+}
+
+func badUnlisted(ctx context.Context) {
+	unlisted(ctx).Info().Msg("unlisted") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badGlobal(ctx context.Context) {
+	log.Info().Msg("global") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
@@ -0,0 +1,30 @@
+// Package rootctx tests the -root-ctx-funcs allowlist for entry points that
+// intentionally log with a fresh root context.
+package rootctx
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type Server struct {
+	logger zerolog.Logger
+}
+
+// Allowed: rootctx.Server.Start
+func (s *Server) Start(ctx context.Context) {
+	s.logger.Info().Ctx(context.Background()).Msg("starting") // OK
+	go func() {
+		s.logger.Info().Ctx(context.Background()).Msg("background loop") // OK - nested in allowed function
+	}()
+}
+
+// Allowed: rootctx.Serve
+func Serve(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(context.TODO()).Msg("serving") // OK
+}
+
+func (s *Server) Handle(ctx context.Context) {
+	s.logger.Info().Ctx(context.Background()).Msg("handling") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
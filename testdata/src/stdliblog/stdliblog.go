@@ -0,0 +1,67 @@
+// Package stdliblog tests -stdlib-log, with log.Fatal allowed and
+// fmt.Fprintln denied. See stdliblog.go.golden for the suggested fixes.
+package stdliblog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+var stdLogger = log.New(os.Stderr, "", 0)
+
+// ===== SHOULD REPORT =====
+
+func badLogPrintf(ctx context.Context) {
+	log.Printf("x %d", 1) // want `log.Printf bypasses context; use Event chain with .Ctx\(ctx\)`
+	log.Println("x")      // want `log.Println bypasses context; use Event chain with .Ctx\(ctx\)`
+	log.Panicf("x")       // want `log.Panicf bypasses context`
+}
+
+func badLoggerMethod(ctx context.Context) {
+	stdLogger.Print("x") // want `log.Logger.Print bypasses context; use Event chain with .Ctx\(ctx\)`
+}
+
+func badSlog(ctx context.Context) {
+	slog.Info("x", "k", 1) // want `slog.Info drops the context; use InfoContext\(ctx, ...\)`
+	slog.Error("x")        // want `slog.Error drops the context; use ErrorContext\(ctx, ...\)`
+}
+
+func badSlogLogger(ctx context.Context, logger *slog.Logger) {
+	logger.Warn("x") // want `slog.Logger.Warn drops the context; use WarnContext\(ctx, ...\)`
+}
+
+func badDenied(ctx context.Context) {
+	fmt.Fprintln(os.Stderr, "x") // want `fmt.Fprintln bypasses context; use Event chain with .Ctx\(ctx\)`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodSlogContext(ctx context.Context, logger *slog.Logger) {
+	slog.InfoContext(ctx, "x")
+	logger.WarnContext(ctx, "x")
+	slog.Log(ctx, slog.LevelInfo, "x")
+}
+
+func goodAllowed(ctx context.Context) {
+	log.Fatal("x")
+}
+
+func goodNotLogging(ctx context.Context) {
+	_ = fmt.Sprintf("x %d", 1)
+	fmt.Fprint(os.Stderr, "x")
+	log.SetFlags(0)
+}
+
+func goodZerolog(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Msg("x")
+}
+
+func goodNoCtx() {
+	log.Printf("x")
+	slog.Info("x")
+}
@@ -0,0 +1,44 @@
+// Package strict tests -strict, where only Event.Ctx and Context.Ctx attach
+// a context. See strict.go.golden for the suggested fixes.
+package strict
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// ===== SHOULD REPORT =====
+
+func badZerologCtx(ctx context.Context) {
+	zerolog.Ctx(ctx).Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badLogCtx(ctx context.Context) {
+	log.Ctx(ctx).Warn().Str("k", "v").Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badWithContext(ctx context.Context, logger zerolog.Logger) context.Context {
+	return logger.WithContext(ctx) // want `zerolog logger stored by WithContext missing .With\(\).Ctx\(ctx\)`
+}
+
+func badWithContextFromCtx(ctx context.Context) context.Context {
+	l := zerolog.Ctx(ctx).With().Str("k", "v").Logger()
+	return l.WithContext(ctx) // want `zerolog logger stored by WithContext missing .With\(\).Ctx\(ctx\)`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodEventCtx(ctx context.Context) {
+	zerolog.Ctx(ctx).Info().Ctx(ctx).Msg("x") // OK
+}
+
+func goodContextCtx(ctx context.Context) {
+	l := zerolog.Ctx(ctx).With().Ctx(ctx).Logger()
+	l.Info().Msg("x") // OK
+}
+
+func goodWithContext(ctx context.Context, logger zerolog.Logger) context.Context {
+	return logger.With().Ctx(ctx).Logger().WithContext(ctx) // OK
+}
@@ -0,0 +1,13 @@
+package suggestfix
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog"
+)
+
+func fixHTTPHandler(logger zerolog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger.Info().Str("path", r.URL.Path).Msg("request") // want `zerolog call chain missing .Ctx\(r.Context\(\)\)`
+	}
+}
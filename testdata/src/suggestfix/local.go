@@ -0,0 +1,19 @@
+package suggestfix
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// Replacing a local logger with zerolog.Ctx(ctx) would leave it unused and
+// drop its writer, so only the .Ctx(ctx) insertion is offered.
+func fixLocalLogger(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.Info().Msg("local") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fixBuiltLogger(ctx context.Context) {
+	zerolog.New(os.Stderr).Info().Msg("built") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
@@ -0,0 +1,7 @@
+package suggestfix
+
+import "context"
+
+func fixNoImport(ctx context.Context, logger Logger) {
+	logger.Info().Msg("no import") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
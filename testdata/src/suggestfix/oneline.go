@@ -0,0 +1,7 @@
+package suggestfix
+
+import ("context")
+
+func fixOneLineImport(ctx context.Context, logger Logger) {
+	logger.Info().Msg("one-line import") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
@@ -0,0 +1,57 @@
+// Package suggestfix contains test fixtures for the suggested fixes of
+// missing .Ctx(ctx) diagnostics. See suggestfix.go.golden for the results.
+package suggestfix
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func fixSimple(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Str("k", "v").Msg("simple") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fixErr(ctx context.Context, logger zerolog.Logger) {
+	logger.Err(errors.New("x")).Send() // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fixWithLevel(ctx context.Context, logger zerolog.Logger) {
+	logger.WithLevel(zerolog.Level(1)).Msgf("level %d", 1) // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fixMultiline(ctx context.Context, logger zerolog.Logger) {
+	logger.Warn().
+		Str("k", "v").
+		Msg("multiline") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fixDerivedLogger(ctx context.Context, logger zerolog.Logger) {
+	logger.With().Str("k", "v").Logger().Info().Msg("derived") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fixVariable(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info()
+	e.Str("k", "v").Msg("variable") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fixBranches(ctx context.Context, logger zerolog.Logger, fail bool) {
+	e := logger.Info()
+	if fail {
+		e = logger.Error()
+	}
+	e.Msg("branches") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fixGlobal(c context.Context) {
+	log.Info().Msg("global") // want `zerolog call chain missing .Ctx\(c\)`
+}
+
+func fixDeferred(ctx context.Context, logger zerolog.Logger) {
+	defer logger.Info().Msg("deferred") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// Logger lets noimport.go log without importing zerolog.
+type Logger = zerolog.Logger
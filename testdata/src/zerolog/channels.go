@@ -0,0 +1,137 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers events and loggers passed through channels.
+package zerolog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type eventQueue struct {
+	events chan *zerolog.Event
+}
+
+var globalEvents = make(chan *zerolog.Event, 1)
+
+func produceWithCtx(ctx context.Context, logger zerolog.Logger, ch chan<- *zerolog.Event) {
+	ch <- logger.Info().Ctx(ctx) // want `zerolog event sent on a channel; events are pooled and not goroutine-safe`
+}
+
+func produceWithoutCtx(logger zerolog.Logger, ch chan<- *zerolog.Event) {
+	ch <- logger.Info() // want `zerolog event sent on a channel; events are pooled and not goroutine-safe`
+}
+
+// ===== SHOULD REPORT =====
+
+func badChannelOneSenderWithoutCtx(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	ch := make(chan *zerolog.Event, 1)
+	if verbose {
+		ch <- logger.Debug().Ctx(ctx)
+	} else {
+		ch <- logger.Info()
+	}
+	e := <-ch
+	e.Msg("from channel") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badChannelCommaOk(ctx context.Context, logger zerolog.Logger) {
+	ch := make(chan *zerolog.Event, 1)
+	ch <- logger.Info()
+	if e, ok := <-ch; ok {
+		e.Msg("from channel") // want `zerolog call chain missing .Ctx\(ctx\)`
+	}
+}
+
+func badChannelHelperSender(ctx context.Context, logger zerolog.Logger) {
+	ch := make(chan *zerolog.Event, 1)
+	produceWithoutCtx(logger, ch)
+	e := <-ch
+	e.Msg("from helper") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badChannelField(ctx context.Context, q *eventQueue) {
+	e := <-q.events
+	e.Msg("from field") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fillQueue(logger zerolog.Logger, q *eventQueue) {
+	q.events <- logger.Info() // want `zerolog event sent on a channel; events are pooled and not goroutine-safe`
+}
+
+func badLoggerChannel(ctx context.Context, logger zerolog.Logger) {
+	loggers := make(chan zerolog.Logger, 1)
+	loggers <- logger
+	l := <-loggers
+	l.Info().Msg("from channel") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badChannelParameter(ctx context.Context, events <-chan *zerolog.Event) {
+	e := <-events
+	e.Msg("unknown senders") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodChannelAllSendersWithCtx(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	ch := make(chan *zerolog.Event, 1)
+	if verbose {
+		ch <- logger.Debug().Ctx(ctx)
+	} else {
+		ch <- logger.Info().Ctx(ctx)
+	}
+	e := <-ch
+	e.Msg("from channel")
+}
+
+func goodChannelCapturedByGoroutine(ctx context.Context, logger zerolog.Logger) {
+	ch := make(chan *zerolog.Event)
+	go func() {
+		ch <- logger.Info().Ctx(ctx) // want `zerolog event sent on a channel; events are pooled and not goroutine-safe`
+	}()
+	e := <-ch
+	e.Msg("from goroutine")
+}
+
+func goodChannelReceivedInClosure(ctx context.Context, logger zerolog.Logger) {
+	ch := make(chan *zerolog.Event, 1)
+	ch <- logger.Info().Ctx(ctx) // want `zerolog event sent on a channel; events are pooled and not goroutine-safe`
+	func() {
+		e := <-ch
+		e.Msg("from closure")
+	}()
+}
+
+func goodChannelHelperSender(ctx context.Context, logger zerolog.Logger) {
+	ch := make(chan *zerolog.Event, 1)
+	produceWithCtx(ctx, logger, ch)
+	e := <-ch
+	e.Msg("from helper")
+}
+
+func goodChannelGlobal(ctx context.Context, logger zerolog.Logger) {
+	globalEvents <- logger.Info().Ctx(ctx) // want `zerolog event sent on a channel; events are pooled and not goroutine-safe`
+	e := <-globalEvents
+	e.Msg("from global")
+}
+
+func goodChannelSelect(ctx context.Context, logger zerolog.Logger) {
+	ch1 := make(chan *zerolog.Event, 1)
+	ch2 := make(chan *zerolog.Event, 1)
+	ch1 <- logger.Info().Ctx(ctx)
+	ch2 <- logger.Warn().Ctx(ctx)
+
+	var e *zerolog.Event
+	select {
+	case e = <-ch1:
+	case e = <-ch2:
+	}
+	e.Msg("from select")
+}
+
+func goodLoggerChannel(ctx context.Context, logger zerolog.Logger) {
+	loggers := make(chan zerolog.Logger, 1)
+	loggers <- logger.With().Ctx(ctx).Logger() // OK - the channel is local
+	l := <-loggers
+	l.Info().Msg("from channel")
+}
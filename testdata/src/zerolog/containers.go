@@ -0,0 +1,172 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers events and loggers stored in slices, arrays and maps.
+package zerolog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type loggerRegistry struct {
+	loggers map[string]zerolog.Logger
+}
+
+func (r *loggerRegistry) register(name string, l zerolog.Logger) {
+	r.loggers[name] = l
+}
+
+// ===== SHOULD REPORT =====
+
+func badSliceOneElementWithoutCtx(ctx context.Context, logger zerolog.Logger, i int) {
+	events := []*zerolog.Event{logger.Info().Ctx(ctx), logger.Warn()}
+	events[i].Msg("from slice") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badSliceAppendWithoutCtx(ctx context.Context, logger zerolog.Logger) {
+	var events []*zerolog.Event
+	events = append(events, logger.Info().Ctx(ctx))
+	events = append(events, logger.Warn())
+	for _, e := range events {
+		e.Msg("from append") // want `zerolog call chain missing .Ctx\(ctx\)`
+	}
+}
+
+func badArrayIndexStoreWithoutCtx(ctx context.Context, logger zerolog.Logger, i int) {
+	var events [2]*zerolog.Event
+	events[0] = logger.Info().Ctx(ctx)
+	events[1] = logger.Warn()
+	events[i].Msg("from array") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badMapUpdateWithoutCtx(ctx context.Context, logger zerolog.Logger) {
+	loggers := map[string]zerolog.Logger{}
+	loggers["a"] = logger.With().Ctx(ctx).Logger()
+	loggers["b"] = logger
+	l := loggers["a"]
+	l.Info().Msg("from map") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badMapRangeWithoutCtx(ctx context.Context, logger zerolog.Logger) {
+	events := map[string]*zerolog.Event{"info": logger.Info()}
+	for _, e := range events {
+		e.Msg("from range") // want `zerolog call chain missing .Ctx\(ctx\)`
+	}
+}
+
+func badSliceFilledByHelper(ctx context.Context, logger zerolog.Logger) {
+	events := make([]*zerolog.Event, 1)
+	fillEvents(logger, events)
+	events[0].Msg("from helper") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func fillEvents(logger zerolog.Logger, events []*zerolog.Event) {
+	events[0] = logger.Info()
+}
+
+func badSliceParameter(ctx context.Context, events []*zerolog.Event) {
+	events[0].Msg("unknown writes") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badRegistryField(ctx context.Context, logger zerolog.Logger, r *loggerRegistry) {
+	r.register("plain", logger)
+	r.loggers["plain"].Info().Msg("from field") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badNestedMapSliceWithoutCtx(ctx context.Context, logger zerolog.Logger) {
+	m := map[string][]*zerolog.Event{}
+	m["a"] = append(m["a"], logger.Info().Ctx(ctx))
+	m["a"] = append(m["a"], logger.Warn())
+	m["a"][0].Msg("from nested") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badNestedSliceWithoutCtx(ctx context.Context, logger zerolog.Logger) {
+	inner := []*zerolog.Event{logger.Info()}
+	events := [][]*zerolog.Event{inner}
+	events[0][0].Msg("from nested") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badNestedParameter(ctx context.Context, logger zerolog.Logger, m map[string][]*zerolog.Event) {
+	m["a"] = append(m["a"], logger.Info().Ctx(ctx))
+	m["a"][0].Msg("unknown writes") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodSliceAllWithCtx(ctx context.Context, logger zerolog.Logger, i int) {
+	events := []*zerolog.Event{logger.Info().Ctx(ctx), logger.Warn().Ctx(ctx)}
+	events[i].Msg("from slice")
+}
+
+func goodSliceAppendWithCtx(ctx context.Context, logger zerolog.Logger) {
+	var events []*zerolog.Event
+	events = append(events, logger.Info().Ctx(ctx))
+	events = append(events, logger.Warn().Ctx(ctx), logger.Error().Ctx(ctx))
+	for _, e := range events {
+		e.Msg("from append")
+	}
+}
+
+func goodSliceOfSlice(ctx context.Context, logger zerolog.Logger) {
+	events := []*zerolog.Event{logger.Info().Ctx(ctx), logger.Warn().Ctx(ctx)}
+	rest := events[1:]
+	rest[0].Msg("from subslice")
+}
+
+func goodArrayIndexStoreWithCtx(ctx context.Context, logger zerolog.Logger, i int) {
+	var events [2]*zerolog.Event
+	events[0] = logger.Info().Ctx(ctx)
+	events[1] = logger.Warn().Ctx(ctx)
+	events[i].Msg("from array")
+}
+
+func goodArrayValue(ctx context.Context, logger zerolog.Logger, i int) {
+	events := [...]zerolog.Logger{logger.With().Ctx(ctx).Logger()}
+	events[i].Info().Msg("from array value")
+}
+
+func goodMapWithCtx(ctx context.Context, logger zerolog.Logger) {
+	loggers := map[string]zerolog.Logger{
+		"a": logger.With().Ctx(ctx).Logger(),
+	}
+	loggers["b"] = logger.With().Str("k", "v").Ctx(ctx).Logger()
+	if l, ok := loggers["a"]; ok {
+		l.Info().Msg("from map")
+	}
+	for _, l := range loggers {
+		l.Info().Msg("from range")
+	}
+}
+
+func goodNestedMapSlice(ctx context.Context, logger zerolog.Logger) {
+	m := map[string][]*zerolog.Event{}
+	m["a"] = append(m["a"], logger.Info().Ctx(ctx))
+	m["a"][0].Msg("from nested")
+}
+
+func goodNestedSlice(ctx context.Context, logger zerolog.Logger, i int) {
+	events := [][]*zerolog.Event{{logger.Info().Ctx(ctx)}}
+	events = append(events, []*zerolog.Event{logger.Warn().Ctx(ctx)})
+	events[i][0].Msg("from nested")
+}
+
+func goodNestedMapRange(ctx context.Context, logger zerolog.Logger) {
+	m := map[string]map[string]zerolog.Logger{
+		"a": {"b": logger.With().Ctx(ctx).Logger()},
+	}
+	for _, inner := range m {
+		if l, ok := inner["b"]; ok {
+			l.Info().Msg("from nested")
+		}
+	}
+}
+
+func goodSliceFilledByHelper(ctx context.Context, logger zerolog.Logger) {
+	events := make([]*zerolog.Event, 1)
+	fillEventsWithCtx(ctx, logger, events)
+	events[0].Msg("from helper")
+}
+
+func fillEventsWithCtx(ctx context.Context, logger zerolog.Logger, events []*zerolog.Event) {
+	events[0] = logger.Info().Ctx(ctx)
+}
@@ -0,0 +1,79 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers parameters whose type embeds context.Context (the default
+// -ctx-types=embedded mode).
+package zerolog
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// RequestCtx is a custom context interface.
+type RequestCtx interface {
+	context.Context
+	UserID() string
+}
+
+// job embeds a context.
+type job struct {
+	context.Context
+	id int
+}
+
+// frameworkCtx implements context.Context without embedding it, like *gin.Context.
+type frameworkCtx struct{ parent context.Context }
+
+func (f *frameworkCtx) Deadline() (time.Time, bool) { return f.parent.Deadline() }
+func (f *frameworkCtx) Done() <-chan struct{}       { return f.parent.Done() }
+func (f *frameworkCtx) Err() error                  { return f.parent.Err() }
+func (f *frameworkCtx) Value(key any) any           { return f.parent.Value(key) }
+func (f *frameworkCtx) Param(name string) string    { return name }
+
+// ===== SHOULD REPORT =====
+
+func badCustomCtxInterface(rc RequestCtx, logger zerolog.Logger) {
+	logger.Info().Str("user", rc.UserID()).Msg("custom") // want `zerolog call chain missing .Ctx\(rc\)`
+}
+
+func badGenericCtx[C context.Context](c C, logger zerolog.Logger) {
+	logger.Info().Msg("generic") // want `zerolog call chain missing .Ctx\(c\)`
+}
+
+func badEmbeddedCtxStruct(j job, logger zerolog.Logger) {
+	logger.Info().Int("id", j.id).Msg("job") // want `zerolog call chain missing .Ctx\(j\)`
+}
+
+func badEmbeddedCtxStructPtr(j *job, logger zerolog.Logger) {
+	logger.Info().Int("id", j.id).Msg("job") // want `zerolog call chain missing .Ctx\(j\)`
+}
+
+func badCustomCtxStale(rc RequestCtx, logger zerolog.Logger) {
+	tctx, cancel := context.WithTimeout(rc, time.Second)
+	defer cancel()
+	_ = tctx
+	logger.Info().Ctx(rc).Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context tctx`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodCustomCtxInterface(rc RequestCtx, logger zerolog.Logger) {
+	logger.Info().Ctx(rc).Msg("custom") // OK
+}
+
+func goodGenericCtx[C context.Context](c C, logger zerolog.Logger) {
+	logger.Info().Ctx(c).Msg("generic") // OK
+}
+
+func goodEmbeddedCtxStruct(j job, logger zerolog.Logger) {
+	logger.Info().Ctx(j).Msg("job") // OK
+}
+
+func goodFrameworkCtxNotEmbedded(fc *frameworkCtx, logger zerolog.Logger) {
+	logger.Info().Msg("framework") // OK - only checked with -ctx-types=all
+}
+
+func goodPrefersUsedCtxParam(rc RequestCtx, ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Msg("used") // OK - the used ctx is preferred over rc
+}
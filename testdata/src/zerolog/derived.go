@@ -0,0 +1,141 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers derived contexts: logging with a parent context while a
+// derived child (timeout, span, errgroup, attached logger) is live, and
+// choosing among several context parameters.
+package zerolog
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+)
+
+type span interface{ End() }
+
+type tracer interface {
+	Start(ctx context.Context, name string) (context.Context, span)
+}
+
+// ===== SHOULD REPORT =====
+
+func badParentAfterTimeout(ctx context.Context, logger zerolog.Logger) {
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_ = tctx
+	logger.Info().Ctx(ctx).Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context tctx`
+}
+
+func badParentAfterSpan(ctx context.Context, tr tracer, logger zerolog.Logger) {
+	sctx, sp := tr.Start(ctx, "op")
+	defer sp.End()
+	_ = sctx
+	logger.Info().Ctx(ctx).Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context sctx`
+}
+
+func badParentInErrgroup(ctx context.Context, logger zerolog.Logger) {
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		logger.Info().Ctx(ctx).Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context gctx`
+		return nil
+	})
+	_ = gctx
+	_ = g.Wait()
+}
+
+func badParentAfterWithContext(ctx context.Context, logger zerolog.Logger) {
+	lctx := logger.WithContext(ctx)
+	_ = lctx
+	zerolog.Ctx(ctx).Info().Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context lctx`
+}
+
+func badParentMostDerived(ctx context.Context, logger zerolog.Logger) {
+	vctx := context.WithValue(ctx, "k", "v")
+	tctx, cancel := context.WithTimeout(vctx, time.Second)
+	defer cancel()
+	_ = tctx
+	logger.Info().Ctx(vctx).Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context tctx`
+}
+
+func badMissingNamesDerived(ctx context.Context, logger zerolog.Logger) {
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_ = tctx
+	logger.Info().Msg("missing") // want `zerolog call chain missing .Ctx\(tctx\)`
+}
+
+func badMissingInClosureNamesDerived(ctx context.Context, logger zerolog.Logger) {
+	gctx := context.WithValue(ctx, "k", "v")
+	_ = gctx
+	go func() {
+		logger.Info().Msg("missing") // want `zerolog call chain missing .Ctx\(gctx\)`
+	}()
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodDerivedCtx(ctx context.Context, logger zerolog.Logger) {
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	logger.Info().Ctx(tctx).Msg("derived") // OK
+}
+
+func goodReassignedCtx(ctx context.Context, tr tracer, logger zerolog.Logger) {
+	ctx, sp := tr.Start(ctx, "op")
+	defer sp.End()
+	logger.Info().Ctx(ctx).Msg("reassigned") // OK - ctx is the child
+}
+
+func goodParentAfterCancel(ctx context.Context, logger zerolog.Logger) {
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	_ = tctx
+	cancel()
+	logger.Info().Ctx(ctx).Msg("child is done") // OK - child cancelled
+}
+
+func goodParentOutsideBranch(ctx context.Context, logger zerolog.Logger, cond bool) {
+	if cond {
+		tctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_ = tctx
+	}
+	logger.Info().Ctx(ctx).Msg("child not live on every path") // OK
+}
+
+func goodParentWithoutCancel(ctx context.Context, logger zerolog.Logger) {
+	bg := context.WithoutCancel(ctx)
+	go func() {
+		logger.Info().Ctx(bg).Msg("detached") // OK
+	}()
+	logger.Info().Ctx(ctx).Msg("parent") // OK - WithoutCancel detaches on purpose
+}
+
+func goodParentBeforeDerive(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Msg("before") // OK
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	logger.Info().Ctx(tctx).Msg("after") // OK
+}
+
+func goodDerivedFromBackground(ctx context.Context, logger zerolog.Logger) {
+	bg, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = bg
+	logger.Info().Msg("missing") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// ===== SEVERAL CONTEXT PARAMETERS =====
+
+func multiCtxPrefersUsed(parent context.Context, ctx context.Context, logger zerolog.Logger) {
+	_ = context.WithoutCancel(ctx)
+	logger.Info().Msg("missing") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func multiCtxSkipsBlank(_ context.Context, ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Msg("missing") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badOnlyBlankCtx(_ context.Context, logger zerolog.Logger) {
+	logger.Info().Msg("blank ctx") // want `zerolog call chain missing .Ctx\(_\)`
+}
@@ -0,0 +1,101 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers loggers with a request context escaping the request.
+package zerolog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	appLogger  zerolog.Logger
+	appContext zerolog.Context
+	loggerMap  = map[string]zerolog.Logger{}
+)
+
+type server struct {
+	log     zerolog.Logger
+	loggers map[string]zerolog.Logger
+	inner   *server
+	out     *zerolog.Logger
+}
+
+// ===== SHOULD REPORT =====
+
+func (s *server) badEscapeField(ctx context.Context, logger zerolog.Logger) {
+	s.log = logger.With().Ctx(ctx).Logger() // want `zerolog logger with a context from .Ctx\(\) escapes to field s.log; its context ends with the request`
+}
+
+func (s *server) badEscapeNestedField(ctx context.Context, logger zerolog.Logger) {
+	s.inner.log = logger.With().Str("k", "v").Ctx(ctx).Logger() // want `zerolog logger with a context from .Ctx\(\) escapes to field s.inner.log`
+}
+
+func badEscapeGlobal(ctx context.Context, logger zerolog.Logger) {
+	l := logger.With().Ctx(ctx).Logger()
+	appLogger = l // want `zerolog logger with a context from .Ctx\(\) escapes to package-level variable appLogger`
+}
+
+func badEscapeGlobalContext(ctx context.Context, logger zerolog.Logger) {
+	appContext = logger.With().Ctx(ctx) // want `escapes to package-level variable appContext`
+}
+
+func badEscapeChannel(ctx context.Context, logger zerolog.Logger, ch chan zerolog.Logger) {
+	ch <- logger.With().Ctx(ctx).Logger() // want `zerolog logger with a context from .Ctx\(\) escapes to a channel`
+}
+
+func badEscapePointerParam(ctx context.Context, logger zerolog.Logger, p *zerolog.Logger) {
+	*p = logger.With().Ctx(ctx).Logger() // want `zerolog logger with a context from .Ctx\(\) escapes to \*p`
+}
+
+func (s *server) badEscapePointerField(ctx context.Context, logger zerolog.Logger) {
+	*s.out = logger.With().Ctx(ctx).Logger() // want `escapes to \*s.out`
+}
+
+func badEscapeGlobalMap(ctx context.Context, logger zerolog.Logger, id string) {
+	loggerMap[id] = logger.With().Ctx(ctx).Logger() // want `escapes to map loggerMap`
+}
+
+func (s *server) badEscapeFieldMap(ctx context.Context, logger zerolog.Logger, id string) {
+	s.loggers[id] = logger.With().Ctx(ctx).Logger() // want `escapes to map s.loggers`
+}
+
+func badEscapeUpdateContext(ctx context.Context) {
+	l := appLogger
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	appLogger = l // want `escapes to package-level variable appLogger`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func (s *server) goodEscapeWithoutCtx(ctx context.Context, logger zerolog.Logger) {
+	s.log = logger.With().Str("k", "v").Logger()
+	appLogger = logger
+}
+
+func (s *server) goodEscapeFromContext(ctx context.Context) {
+	s.log = *zerolog.Ctx(ctx)
+}
+
+func goodEscapeLocal(ctx context.Context, logger zerolog.Logger) {
+	s := &server{}
+	s.log = logger.With().Ctx(ctx).Logger()
+	s.log.Info().Msg("x")
+}
+
+func goodEscapeLocalPointer(ctx context.Context, logger zerolog.Logger) {
+	var l zerolog.Logger
+	p := &l
+	*p = logger.With().Ctx(ctx).Logger()
+	l.Info().Msg("x")
+}
+
+func goodEscapeSomePaths(ctx context.Context, logger zerolog.Logger, bind bool) {
+	l := logger
+	if bind {
+		l = logger.With().Ctx(ctx).Logger()
+	}
+	appLogger = l
+}
@@ -227,7 +227,7 @@ type myProvider struct {
 	logger zerolog.Logger
 }
 
-func (p *myProvider) GetLogger() zerolog.Logger {
+func (p *myProvider) GetLogger() zerolog.Logger { // want GetLogger:"returns\\(without-ctx\\)"
 	return p.logger
 }
 
@@ -7,11 +7,9 @@
 // KNOWN LIMITATIONS (search for "LIMITATION" or "limitation" to find test cases):
 //
 // False Negatives (should report but doesn't):
-//   - Helper returns: Cross-function tracking not supported (except IIFE)
 //   - Deep FreeVar: Triple-nested closures
 //
 // False Positives (reports when shouldn't):
-//   - Channel send/receive: Can't trace through channels
 //   - sync.Pool: Can't trace through Get/Put
 //   - Embedded struct: `h.Msg()` where h embeds *Event
 //   - Closure-modified capture: Closure writes to outer var
@@ -98,10 +96,10 @@ func badHelperChainedImmediately(ctx context.Context, logger zerolog.Logger) {
 	createEvent(logger).Str("key", "val").Msg("immediate chain") // want `zerolog call chain missing .Ctx\(ctx\)`
 }
 
-func limitationHelperWithCtx(ctx context.Context, logger zerolog.Logger) {
-	// LIMITATION: SSA doesn't track .Ctx() through helper function returns
+func goodHelperWithCtx(ctx context.Context, logger zerolog.Logger) {
+	// Helper return is summarized: createEventWithCtx always sets ctx
 	e := createEventWithCtx(ctx, logger)
-	e.Msg("helper with ctx") // want `zerolog call chain missing .Ctx\(ctx\)`
+	e.Msg("helper with ctx") // OK
 }
 
 func createEvent(logger zerolog.Logger) *zerolog.Event {
@@ -261,10 +259,10 @@ func badEventFromMap(ctx context.Context, logger zerolog.Logger) {
 	events["info"].Msg("from map") // want `zerolog call chain missing .Ctx\(ctx\)`
 }
 
-func limitationEventFromSliceWithCtx(ctx context.Context, logger zerolog.Logger) {
-	// LIMITATION: SSA doesn't track events through slice/array access
+func goodEventFromSliceWithCtx(ctx context.Context, logger zerolog.Logger) {
+	// Elements are traced to every value written into the slice
 	events := []*zerolog.Event{logger.Info().Ctx(ctx)}
-	events[0].Msg("from slice with ctx") // want `zerolog call chain missing .Ctx\(ctx\)`
+	events[0].Msg("from slice with ctx") // OK
 }
 
 // ===== POINTER INDIRECTION =====
@@ -439,13 +437,12 @@ func badEventFromChannel(ctx context.Context, logger zerolog.Logger) {
 	e.Msg("from channel") // want `zerolog call chain missing .Ctx\(ctx\)`
 }
 
-// LIMITATION (false positive): Channel send/receive breaks value tracking.
-// Even though ctx is present, the analyzer cannot trace through channel operations.
-func limitationChannelWithCtx(ctx context.Context, logger zerolog.Logger) {
+// Receives are traced to the values sent on the same channel.
+func goodChannelWithCtx(ctx context.Context, logger zerolog.Logger) {
 	ch := make(chan *zerolog.Event, 1)
 	ch <- logger.Info().Ctx(ctx)
 	e := <-ch
-	e.Msg("from channel with ctx") // want `zerolog call chain missing .Ctx\(ctx\)`
+	e.Msg("from channel with ctx") // OK - the only value sent has ctx
 }
 
 func badSelectStatementChannel(ctx context.Context, logger zerolog.Logger) {
@@ -785,7 +782,7 @@ type eventGetterImpl struct {
 	e *zerolog.Event
 }
 
-func (h *eventGetterImpl) GetEvent() *zerolog.Event {
+func (h *eventGetterImpl) GetEvent() *zerolog.Event { // want GetEvent:"returns\\(without-ctx\\)"
 	return h.e
 }
 
@@ -812,15 +809,15 @@ func badNamedReturnHelper(ctx context.Context, logger zerolog.Logger) {
 // ===== BLANK IDENTIFIER =====
 
 func badBlankIdentifier(ctx context.Context, logger zerolog.Logger) {
-	_ = logger.Info().Ctx(ctx)
+	_ = logger.Info().Ctx(ctx)                // want `zerolog event from Info\(\) is never sent`
 	logger.Warn().Msg("not the one with ctx") // want `zerolog call chain missing .Ctx\(ctx\)`
 }
 
 // ===== REASSIGNMENT SHADOWS =====
 
 func badReassignmentShadows(ctx context.Context, logger zerolog.Logger) {
-	e := logger.Info().Ctx(ctx)
-	_ = e // use e
+	e := logger.Info().Ctx(ctx) // want `zerolog event from Info\(\) is never sent`
+	_ = e                       // use e
 	e = logger.Warn()
 	e.Msg("shadowed event") // want `zerolog call chain missing .Ctx\(ctx\)`
 }
@@ -865,10 +862,33 @@ func (h *ptrHolder) log() {
 	h.e.Msg("ptr holder")
 }
 
-// LIMITATION: Method call on struct requires cross-function tracking
-func limitationPtrReceiverMethod(ctx context.Context, logger zerolog.Logger) {
+// The method has no ctx, so the event it logs is reported at the call site
+func badPtrReceiverMethod(ctx context.Context, logger zerolog.Logger) {
 	h := &ptrHolder{e: logger.Info()}
-	h.log() // LIMITATION: should report inside h.log() but doesn't
+	h.log() // want `zerolog call chain missing .Ctx\(ctx\) before passing to log`
+}
+
+func goodPtrReceiverMethod(ctx context.Context, logger zerolog.Logger) {
+	h := &ptrHolder{e: logger.Info().Ctx(ctx)}
+	h.log() // OK
+}
+
+type valueHolder struct {
+	e *zerolog.Event
+}
+
+func (h valueHolder) log() {
+	h.e.Msg("value holder")
+}
+
+func badValueReceiverMethod(ctx context.Context, logger zerolog.Logger) {
+	h := valueHolder{e: logger.Info()}
+	h.log() // want `zerolog call chain missing .Ctx\(ctx\) before passing to log`
+}
+
+func goodValueReceiverMethod(ctx context.Context, logger zerolog.Logger) {
+	h := valueHolder{e: logger.Info().Ctx(ctx)}
+	h.log() // OK
 }
 
 // ===== CLOSURE THAT MODIFIES CAPTURED VAR =====
@@ -914,10 +934,12 @@ type loggerHolder struct {
 	logger zerolog.Logger
 }
 
-// Note: This method has no ctx parameter, so analyzer won't report.
-// The ctx field in the struct is not tracked as a context source.
+// Note: This method has no ctx parameter, so analyzer won't report inside.
+// The ctx field in the struct is not tracked as a context source unless
+// -ctx-fields is set (see testdata/src/ctxfields); instead, callers passing a
+// logger without ctx are reported.
 func (h *loggerHolder) logBad() {
-	h.logger.Info().Msg("struct method") // No ctx param - not reported
+	h.logger.Info().Msg("struct method") // No ctx param - reported at call site
 }
 
 func (h *loggerHolder) logGood() {
@@ -926,7 +948,7 @@ func (h *loggerHolder) logGood() {
 
 func badStructMethodCall(ctx context.Context, logger zerolog.Logger) {
 	h := &loggerHolder{ctx: ctx, logger: logger}
-	h.logBad() // The error is inside logBad, not here
+	h.logBad() // want `zerolog call chain missing .Ctx\(ctx\) before passing to logBad`
 }
 
 func goodStructMethodCall(ctx context.Context, logger zerolog.Logger) {
@@ -1133,7 +1155,7 @@ type eventProviderImpl struct {
 	e *zerolog.Event
 }
 
-func (p *eventProviderImpl) GetEvent() *zerolog.Event {
+func (p *eventProviderImpl) GetEvent() *zerolog.Event { // want GetEvent:"returns\\(without-ctx\\)"
 	return p.e
 }
 
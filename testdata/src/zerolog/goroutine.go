@@ -0,0 +1,107 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers events shared across goroutines.
+package zerolog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+)
+
+// ===== SHOULD REPORT =====
+
+func badSharedCaptured(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	go func() { // want `zerolog event e shared with a goroutine; events are pooled and not goroutine-safe`
+		e.Msg("done")
+	}()
+}
+
+func badSharedHalfBuilt(ctx context.Context, logger zerolog.Logger, done chan struct{}) {
+	e := logger.Info().Ctx(ctx).Str("k", "v")
+	go func() { // want `zerolog event e shared with a goroutine`
+		<-done
+		e.Int("n", 1).Send()
+	}()
+}
+
+func badSharedReassigned(logger zerolog.Logger) {
+	var e *zerolog.Event
+	e = logger.Info()
+	go func() { // want `zerolog event e shared with a goroutine`
+		e.Send()
+	}()
+	e = nil
+}
+
+func badSharedErrgroup(ctx context.Context, logger zerolog.Logger) error {
+	g, gctx := errgroup.WithContext(ctx)
+	e := logger.Info().Ctx(gctx)
+	g.Go(func() error { // want `zerolog event e shared with the errgroup.Group.Go callback`
+		e.Send()
+		return nil
+	})
+	return g.Wait()
+}
+
+func badSharedArgument(ctx context.Context, logger zerolog.Logger) {
+	go sendLater(logger.Info().Ctx(ctx)) // want `zerolog event passed to a goroutine`
+}
+
+func badSharedBoundMethod(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	go e.Msg("done") // want `zerolog event passed to a goroutine`
+}
+
+func badSharedChannel(ctx context.Context, logger zerolog.Logger, events chan<- *zerolog.Event) {
+	events <- logger.Info().Ctx(ctx) // want `zerolog event sent on a channel; events are pooled and not goroutine-safe`
+}
+
+func badSharedLocalChannelEscapes(logger zerolog.Logger) {
+	ch := make(chan *zerolog.Event, 1)
+	go func() {
+		(<-ch).Send()
+	}()
+	ch <- logger.Info() // want `zerolog event sent on a channel`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodEventInsideGoroutine(ctx context.Context, logger zerolog.Logger) {
+	go func() {
+		logger.Info().Ctx(ctx).Msg("done")
+	}()
+}
+
+func goodVariableAssignedInsideGoroutine(logger zerolog.Logger) {
+	var e *zerolog.Event
+	go func() {
+		e = logger.Info()
+		e.Send()
+	}()
+}
+
+func goodEventInsideErrgroup(ctx context.Context, logger zerolog.Logger) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		logger.Info().Ctx(gctx).Send()
+		return nil
+	})
+	return g.Wait()
+}
+
+func goodLocalChannel(logger zerolog.Logger) {
+	ch := make(chan *zerolog.Event, 1)
+	ch <- logger.Info()
+	e := <-ch
+	close(ch)
+	e.Msg("same goroutine")
+}
+
+func goodSynchronousClosure(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	func() {
+		e.Msg("same goroutine")
+	}()
+}
@@ -0,0 +1,72 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers HTTP handlers, whose context is provided by the
+// *http.Request parameter.
+package zerolog
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+)
+
+// ===== SHOULD REPORT =====
+
+func badHTTPHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info().Msg("request") // want `zerolog call chain missing .Ctx\(r.Context\(\)\)`
+}
+
+func badHTTPHandlerLogger(logger zerolog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		logger.Info().Msg("request") // want `zerolog call chain missing .Ctx\(req.Context\(\)\)`
+	}
+}
+
+func badHTTPHandlerRootCtx(w http.ResponseWriter, r *http.Request) {
+	log.Info().Ctx(context.Background()).Msg("request") // want `zerolog .Ctx\(\) is given a root context instead of r.Context\(\)`
+}
+
+func badHTTPHandlerClosure(w http.ResponseWriter, r *http.Request) {
+	go func() {
+		log.Info().Msg("async") // want `zerolog call chain missing .Ctx\(r.Context\(\)\)`
+	}()
+}
+
+func badHTTPHandlerStaleCtx(w http.ResponseWriter, r *http.Request) {
+	tctx, cancel := context.WithTimeout(r.Context(), time.Second)
+	defer cancel()
+	_ = tctx
+	log.Info().Ctx(r.Context()).Msg("stale") // want `zerolog .Ctx\(\) is given a parent of the derived context tctx`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodHTTPHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info().Ctx(r.Context()).Msg("request") // OK
+}
+
+func goodHTTPHandlerHlog(w http.ResponseWriter, r *http.Request) {
+	hlog.FromRequest(r).Info().Msg("request") // OK - logger from the request context
+}
+
+func goodHTTPHandlerHlogDerived(w http.ResponseWriter, r *http.Request) {
+	l := hlog.FromRequest(r).With().Str("path", r.URL.Path).Logger()
+	l.Info().Msg("request") // OK
+}
+
+func badHTTPHandlerPrefersCtx(ctx context.Context, r *http.Request) {
+	log.Info().Msg("request") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func goodHTTPHandlerDerived(w http.ResponseWriter, r *http.Request) {
+	tctx, cancel := context.WithTimeout(r.Context(), time.Second)
+	defer cancel()
+	log.Info().Ctx(tctx).Msg("derived") // OK
+}
+
+func goodHTTPBlankRequest(w http.ResponseWriter, _ *http.Request) {
+	log.Info().Msg("no usable request") // OK - blank request cannot be used
+}
@@ -0,0 +1,132 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers functions without a context parameter that create a
+// context in their body (main, workers, cron jobs).
+package zerolog
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// ===== SHOULD REPORT =====
+
+func badLocalNotifyContext() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	_ = ctx
+	log.Info().Msg("started") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+var appCtx = context.Background()
+
+func badLocalWithoutCancel(logger zerolog.Logger) {
+	bg := context.WithoutCancel(appCtx)
+	_ = bg
+	logger.Info().Msg("detached") // want `zerolog call chain missing .Ctx\(bg\)`
+}
+
+func badLocalBackground(logger zerolog.Logger) {
+	ctx := context.Background()
+	_ = ctx
+	logger.Info().Msg("cron") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badLocalInClosure(logger zerolog.Logger) {
+	jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	go func() {
+		logger.Info().Msg("worker") // want `zerolog call chain missing .Ctx\(jobCtx\)`
+	}()
+	<-jobCtx.Done()
+}
+
+func badLocalMostRecent(logger zerolog.Logger) {
+	ctx := context.Background()
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_ = tctx
+	logger.Info().Msg("run")          // want `zerolog call chain missing .Ctx\(tctx\)`
+	logger.Info().Ctx(ctx).Msg("run") // want `zerolog .Ctx\(\) is given a parent of the derived context tctx`
+}
+
+func badLocalRootArg(logger zerolog.Logger) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger.Info().Ctx(context.TODO()).Msg("run") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+	_ = ctx
+}
+
+func badLocalGlobalArg(logger zerolog.Logger) {
+	ctx := context.Background()
+	_ = ctx
+	logger.Info().Ctx(appCtx).Msg("run") // want `zerolog .Ctx\(\) is given a context not derived from ctx`
+}
+
+func badLocalRootArgInClosure(logger zerolog.Logger) {
+	jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	go func() {
+		logger.Info().Ctx(context.Background()).Msg("worker") // want `zerolog .Ctx\(\) is given a root context instead of jobCtx`
+	}()
+	<-jobCtx.Done()
+}
+
+// A consumer checked against its own context: reported here, not at its
+// callers, and it exports no fact.
+func badLocalConsumer(e *zerolog.Event) {
+	ctx := context.Background()
+	_ = ctx
+	e.Msg("consumed") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodLocalConsumerCaller(ctx context.Context, logger zerolog.Logger) {
+	badLocalConsumer(logger.Info()) // OK - reported in badLocalConsumer
+}
+
+func goodLocalCtx() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	log.Info().Ctx(ctx).Msg("started") // OK
+}
+
+func goodLocalBeforeCtx(logger zerolog.Logger) {
+	logger.Info().Msg("before any context") // OK - no context yet
+	ctx := context.Background()
+	logger.Info().Ctx(ctx).Msg("after") // OK - a local root context is fine
+}
+
+func goodLocalInClosure(logger zerolog.Logger) {
+	jobCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	go func() {
+		logger.Info().Ctx(jobCtx).Msg("worker") // OK
+	}()
+	<-jobCtx.Done()
+}
+
+func goodLocalDerivedArg(logger zerolog.Logger) {
+	ctx := context.WithoutCancel(appCtx)
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	logger.Info().Ctx(tctx).Msg("run") // OK
+}
+
+func goodLocalInBranch(logger zerolog.Logger, cond bool) {
+	if cond {
+		ctx := context.Background()
+		logger.Info().Ctx(ctx).Msg("branch") // OK
+	}
+	logger.Info().Msg("no context on every path") // OK
+}
+
+func goodLocalUnnamed(logger zerolog.Logger) {
+	_ = context.Background()
+	logger.Info().Msg("unnamed") // OK
+}
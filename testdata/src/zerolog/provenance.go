@@ -0,0 +1,139 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers the provenance of contexts passed to .Ctx() and zerolog.Ctx():
+// contexts not derived from the function's one are reported.
+package zerolog
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// ===== SHOULD REPORT =====
+
+func badCtxBackground(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(context.Background()).Msg("background") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
+
+func badCtxTODO(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(context.TODO()).Msg("todo") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
+
+func badCtxNil(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(nil).Msg("nil") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
+
+func badLogCtxBackground(ctx context.Context) {
+	log.Ctx(context.Background()).Info().Msg("log.Ctx") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
+
+func badZerologCtxTODO(ctx context.Context) {
+	zerolog.Ctx(context.TODO()).Info().Msg("zerolog.Ctx") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
+
+func badContextCtxBackground(ctx context.Context, logger zerolog.Logger) {
+	l := logger.With().Ctx(context.Background()).Logger() // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+	l.Info().Msg("derived")
+}
+
+func badDerivedFromBackground(ctx context.Context, logger zerolog.Logger) {
+	bg, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	logger.Info().Ctx(bg).Msg("derived from background") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
+
+func badRootInVariable(ctx context.Context, logger zerolog.Logger) {
+	root := context.Background()
+	logger.Info().Ctx(root).Msg("variable") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
+
+func badRootInClosure(ctx context.Context, logger zerolog.Logger) {
+	func() {
+		logger.Info().Ctx(context.TODO()).Msg("closure") // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+	}()
+}
+
+var globalCtx = context.Background()
+
+type ctxHolder struct {
+	ctx context.Context
+}
+
+var globalHolder ctxHolder
+
+func newCtx() context.Context { return context.Background() }
+
+func badCtxGlobal(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(globalCtx).Msg("global") // want `zerolog .Ctx\(\) is given a context not derived from ctx`
+}
+
+func badCtxForeignField(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(globalHolder.ctx).Msg("field") // want `zerolog .Ctx\(\) is given a context not derived from ctx`
+}
+
+func badCtxParamField(ctx context.Context, h *ctxHolder, logger zerolog.Logger) {
+	logger.Info().Ctx(h.ctx).Msg("field without -ctx-fields") // want `zerolog .Ctx\(\) is given a context not derived from ctx`
+}
+
+func badCtxCallResult(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(newCtx()).Msg("call") // want `zerolog .Ctx\(\) is given a context not derived from ctx`
+}
+
+func badCtxDerivedFromGlobal(ctx context.Context, logger zerolog.Logger) {
+	gctx, cancel := context.WithCancel(globalCtx)
+	defer cancel()
+	logger.Info().Ctx(gctx).Msg("derived from global") // want `zerolog .Ctx\(\) is given a context not derived from ctx`
+}
+
+func badCtxBranches(ctx context.Context, logger zerolog.Logger, detach bool) {
+	c := ctx
+	if detach {
+		c = context.Background()
+	}
+	logger.Info().Ctx(c).Msg("one branch is root") // want `zerolog .Ctx\(\) is given a context not derived from ctx`
+}
+
+func badCtxDeferred(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	defer e.Msg("done")
+	defer e.Ctx(context.TODO()) // want `zerolog .Ctx\(\) is given a root context instead of ctx`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodCtxDerived(ctx context.Context, logger zerolog.Logger) {
+	child, cancel := context.WithCancel(ctx)
+	defer cancel()
+	logger.Info().Ctx(child).Msg("derived") // OK
+}
+
+func goodCtxWithoutCancel(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(context.WithoutCancel(ctx)).Msg("without cancel") // OK
+}
+
+func goodCtxInLocalStruct(ctx context.Context, logger zerolog.Logger) {
+	h := ctxHolder{ctx: ctx}
+	logger.Info().Ctx(h.ctx).Msg("struct built here") // OK
+}
+
+func goodCtxCaptured(ctx context.Context, logger zerolog.Logger) {
+	func() {
+		logger.Info().Ctx(ctx).Msg("captured") // OK
+	}()
+}
+
+func goodCtxValue(ctx context.Context, logger zerolog.Logger) {
+	vctx := context.WithValue(ctx, ctxHolder{}, "v")
+	logger.Info().Ctx(vctx).Msg("derived by a call") // OK
+}
+
+func goodRootWithoutCtxParam(logger zerolog.Logger) {
+	logger.Info().Ctx(context.Background()).Msg("no ctx available") // OK
+}
+
+func goodRootIgnored(ctx context.Context, logger zerolog.Logger) {
+	//zerologlintctx:ignore - detached on purpose
+	logger.Info().Ctx(context.Background()).Msg("ignored")
+}
@@ -0,0 +1,124 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers events used after they were sent.
+package zerolog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+// ===== SHOULD REPORT =====
+
+func badReuseTwice(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	e.Msg("a")
+	e.Msg("b") // want `zerolog event used after .Msg\(\) returned it to the pool`
+}
+
+func badReuseFieldAfterSend(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	e.Send()
+	e.Str("k", "v") // want `zerolog event used after .Send\(\) returned it to the pool`
+}
+
+func badReuseInLoop(ctx context.Context, logger zerolog.Logger, vs []string) {
+	e := logger.Info().Ctx(ctx)
+	for _, v := range vs {
+		e.Str("v", v).Send() // want `zerolog event used after .Send\(\) returned it to the pool`
+	}
+}
+
+func badReuseDerived(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	e2 := e.Str("k", "v")
+	e2.Msg("a")
+	e.Msg("b") // want `zerolog event used after .Msg\(\) returned it to the pool`
+}
+
+func badReuseInBranch(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	e := logger.Info().Ctx(ctx)
+	if verbose {
+		e.Msg("verbose")
+	}
+	e.Msg("done") // want `zerolog event used after .Msg\(\) returned it to the pool`
+}
+
+func badReuseCaptured(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	e.Msg("a")
+	_ = &e
+	e.Msg("b") // want `zerolog event used after .Msg\(\) returned it to the pool`
+}
+
+func badReusePassed(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	e.Msg("a")
+	sendLater(e) // want `zerolog event used after .Msg\(\) returned it to the pool`
+}
+
+func badReuseMerged(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	e := logger.Info().Ctx(ctx)
+	if verbose {
+		e = e.Str("k", "v")
+	}
+	e.Msg("a")
+	e.Msg("b") // want `zerolog event used after .Msg\(\) returned it to the pool`
+}
+
+func badReuseMergedAfterSend(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	e := logger.Info().Ctx(ctx)
+	e.Msg("a")
+	if verbose {
+		e = e.Str("k", "v") // want `zerolog event used after .Msg\(\) returned it to the pool`
+	}
+	e.Msg("b") // want `zerolog event used after .Msg\(\) returned it to the pool`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodSendOnce(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	e := logger.Info().Ctx(ctx)
+	if verbose {
+		e.Msg("verbose")
+		return
+	}
+	e.Msg("done")
+}
+
+func goodSendOnEachBranch(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	e := logger.Info().Ctx(ctx)
+	if verbose {
+		e.Msg("verbose")
+	} else {
+		e.Msg("quiet")
+	}
+}
+
+func goodNewEventPerIteration(ctx context.Context, logger zerolog.Logger, vs []string) {
+	for _, v := range vs {
+		e := logger.Info().Ctx(ctx)
+		e.Str("v", v).Send()
+	}
+}
+
+func goodReassigned(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	e.Msg("a")
+	e = logger.Warn().Ctx(ctx)
+	e.Msg("b")
+}
+
+func goodReassignedInLoop(ctx context.Context, logger zerolog.Logger, vs []string) {
+	var e *zerolog.Event
+	for _, v := range vs {
+		e = logger.Info().Ctx(ctx)
+		e.Str("v", v).Send()
+	}
+}
+
+func goodDeferredSend(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	defer e.Msg("done")
+	e.Str("k", "v")
+}
@@ -0,0 +1,96 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers events created by a level call but never sent.
+package zerolog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func sendLater(e *zerolog.Event) {
+	e.Send()
+}
+
+// ===== SHOULD REPORT =====
+
+func badUnsentChain(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Str("k", "v") // want `zerolog event from Info\(\) is never sent; end the chain with .Msg\(\) or .Send\(\)`
+}
+
+func badUnsentNoCtx(logger zerolog.Logger) {
+	logger.Error().Err(nil) // want `zerolog event from Error\(\) is never sent`
+}
+
+func badUnsentGlobal() {
+	log.Warn().Int("n", 1) // want `zerolog event from Warn\(\) is never sent`
+}
+
+func badUnsentVariable(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Debug().Ctx(ctx) // want `zerolog event from Debug\(\) is never sent`
+	e = e.Str("k", "v")
+	if e.Enabled() {
+		e.Int("n", 1)
+	}
+}
+
+func badUnsentBranches(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	var e *zerolog.Event
+	if verbose {
+		e = logger.Debug() // want `zerolog event from Debug\(\) is never sent`
+	} else {
+		e = logger.Info() // want `zerolog event from Info\(\) is never sent`
+	}
+	if e != nil {
+		e.Ctx(ctx).Str("k", "v")
+	}
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodSent(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Send()
+	e := logger.Warn().Ctx(ctx)
+	e.Str("k", "v").Msg("x")
+}
+
+func goodSentInBranch(ctx context.Context, logger zerolog.Logger, verbose bool) {
+	e := logger.Info().Ctx(ctx)
+	if verbose {
+		e = e.Str("k", "v")
+	}
+	e.Msg("x")
+}
+
+func goodDiscard(ctx context.Context, logger zerolog.Logger) {
+	logger.Debug().Ctx(ctx).Discard()
+}
+
+func goodReturned(logger zerolog.Logger) *zerolog.Event {
+	return logger.Info()
+}
+
+func goodPassed(ctx context.Context, logger zerolog.Logger) {
+	sendLater(logger.Info().Ctx(ctx))
+}
+
+func goodDict(ctx context.Context, logger zerolog.Logger) {
+	logger.Info().Ctx(ctx).Dict("d", zerolog.Dict().Str("k", "v")).Msg("x")
+}
+
+func goodBoundMethod(ctx context.Context, logger zerolog.Logger) {
+	msg := logger.Info().Ctx(ctx).Msg
+	msg("x")
+}
+
+func goodDeferred(ctx context.Context, logger zerolog.Logger) {
+	defer logger.Info().Ctx(ctx).Msg("x")
+}
+
+func goodCaptured(ctx context.Context, logger zerolog.Logger) {
+	e := logger.Info().Ctx(ctx)
+	func() {
+		e.Msg("x")
+	}()
+}
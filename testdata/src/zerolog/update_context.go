@@ -0,0 +1,137 @@
+// Package zerolog contains test fixtures for the zerolog context propagation checker.
+// This file covers loggers given a context in place by Logger.UpdateContext.
+package zerolog
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+func setCtx(c zerolog.Context) zerolog.Context {
+	return c.Ctx(appCtx)
+}
+
+type middleware struct {
+	logger zerolog.Logger
+}
+
+// ===== SHOULD REPORT =====
+
+func badUpdateContextWithoutCtx(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Str("k", "v")
+	})
+	l.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badUpdateContextAfterLog(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+}
+
+func badUpdateContextInBranch(ctx context.Context, enabled bool) {
+	l := zerolog.New(os.Stdout)
+	if enabled {
+		l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+			return c.Ctx(ctx)
+		})
+	}
+	l.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badUpdateContextReassigned(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	l = zerolog.New(os.Stderr)
+	l.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badUpdateContextReassignedInBranch(ctx context.Context, reset bool) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	if reset {
+		l = zerolog.New(os.Stderr)
+	}
+	l.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+func badUpdateContextSomeReturn(ctx context.Context, enabled bool) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		if enabled {
+			return c.Ctx(ctx)
+		}
+		return c
+	})
+	l.Info().Msg("x") // want `zerolog call chain missing .Ctx\(ctx\)`
+}
+
+// ===== SHOULD NOT REPORT =====
+
+func goodUpdateContextLocal(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	l.Info().Msg("x")                               // OK
+	l.With().Str("k", "v").Logger().Warn().Msg("x") // OK - derived loggers keep the context
+}
+
+func goodUpdateContextInLoop(ctx context.Context, n int) {
+	l := zerolog.New(os.Stdout)
+	for i := 0; i < n; i++ {
+		l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+			return c.Ctx(ctx)
+		})
+		l.Info().Msg("x") // OK - the reassignment below runs before the next update
+		l = zerolog.New(os.Stderr)
+	}
+}
+
+func goodUpdateContextChain(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Str("k", "v").Ctx(ctx).Int("n", 1)
+	})
+	l.Info().Msg("x") // OK
+}
+
+func goodUpdateContextPointer(ctx context.Context, l *zerolog.Logger) {
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	l.Info().Msg("x") // OK
+}
+
+func goodUpdateContextAddress(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	p := &l
+	p.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	l.Info().Msg("x") // OK
+	p.Info().Msg("x") // OK - same logger through the pointer
+}
+
+func goodUpdateContextField(ctx context.Context, m *middleware) {
+	m.logger.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	m.logger.Info().Msg("x") // OK
+}
+
+func goodUpdateContextNamedFunc(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(setCtx)
+	l.Info().Msg("x") // OK - setCtx sets the context
+}